@@ -1,20 +1,24 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"go/types"
 	"log"
 	"os"
 	"strings"
 
-	"github.com/pkg/errors"
-	"golang.org/x/tools/go/packages"
-	"golang.org/x/tools/go/ssa"
-
 	"github.com/paultyng/go-typemapper/generator"
 )
 
+var flags generator.Flags
+
+func init() {
+	flags.Register(flag.CommandLine)
+}
+
 func main() {
+	flag.Parse()
+
 	err := mainErr()
 	if err != nil {
 		log.Fatal(err)
@@ -22,40 +26,10 @@ func main() {
 }
 
 func mainErr() error {
-	cfg := &packages.Config{
-		Mode:       packages.LoadSyntax,
-		BuildFlags: []string{"-tags", generator.BuildTag, "-a"},
-		Tests:      false,
-	}
-	pkgs, err := packages.Load(cfg, "./")
+	ssapkg, err := generator.Load("")
 	if err != nil {
 		return err
 	}
-	if len(pkgs) != 1 {
-		return errors.Errorf("error: %d packages found", len(pkgs))
-	}
-	pkg := pkgs[0]
-
-	prog := ssa.NewProgram(pkg.Fset, ssa.BuilderMode(0))
-
-	// Create SSA packages for all imports.
-	// Order is not significant.
-	created := make(map[*types.Package]bool)
-	var createAll func(pkgs []*types.Package)
-	createAll = func(pkgs []*types.Package) {
-		for _, p := range pkgs {
-			if !created[p] {
-				created[p] = true
-				prog.CreatePackage(p, nil, nil, true)
-				createAll(p.Imports())
-			}
-		}
-	}
-	createAll(pkg.Types.Imports())
-
-	// Create and build the primary package.
-	ssapkg := prog.CreatePackage(pkg.Types, pkg.Syntax, pkg.TypesInfo, false)
-	ssapkg.Build()
 
 	//ssapkg.WriteTo(os.Stdout)
 
@@ -64,14 +38,20 @@ func mainErr() error {
 		// code generation comment
 		fmt.Sprintf("// Code generated by \"typemapper %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " ")),
 		// inverse of typemapper build tags
-		fmt.Sprintf("// +build !%s", generator.BuildTag),
+		fmt.Sprintf("//go:build !%s", generator.BuildTag),
 	)
 
+	g = flags.Configure(g)
+
 	err = g.GenerateMappings()
 	if err != nil {
 		return err
 	}
 
+	for _, d := range g.Diagnostics() {
+		fmt.Fprintln(os.Stderr, d)
+	}
+
 	for _, fileName := range g.AllFiles() {
 		fileName := fileName
 		err = func() error {
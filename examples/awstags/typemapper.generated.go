@@ -1,6 +1,6 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package awstags
 
@@ -1,18 +1,161 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package awstags
 
-import "testing"
+import (
+	acm "github.com/aws/aws-sdk-go/service/acm"
+	datasync "github.com/aws/aws-sdk-go/service/datasync"
+	directoryservice "github.com/aws/aws-sdk-go/service/directoryservice"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	"reflect"
+	"strings"
+	"testing"
+)
 
-func TestACMTag(t *testing.T)               {}
-func TestACMTags(t *testing.T)              {}
-func TestDataSyncTag(t *testing.T)          {}
-func TestDataSyncTags(t *testing.T)         {}
-func TestDirectoryServiceTag(t *testing.T)  {}
-func TestDirectoryServiceTags(t *testing.T) {}
-func TestEC2Tag(t *testing.T)               {}
-func TestEC2Tags(t *testing.T)              {}
-func TestELBV2Tag(t *testing.T)             {}
-func TestELBV2Tags(t *testing.T)            {}
+func TestACMTag(t *testing.T) {
+	src := new(tag)
+	src.Key = "src.Key 1"
+	src.Value = "src.Value 2"
+	dst := src.ACMTag()
+	if dst.Key == nil || *dst.Key != "src.Key 1" {
+		t.Errorf("dst.Key does not point to %#v", "src.Key 1")
+	}
+	if dst.Value == nil || *dst.Value != "src.Value 2" {
+		t.Errorf("dst.Value does not point to %#v", "src.Value 2")
+	}
+}
+
+// staleFields returns the fields of ty that are not known, prefixed with name.
+func staleFields(name string, ty reflect.Type, exported bool, known ...string) []string {
+	seen := map[string]bool{}
+	for _, k := range known {
+		seen[k] = true
+	}
+	fields := []string{}
+	for i := 0; i < ty.NumField(); i++ {
+		f := ty.Field(i)
+		if exported && !f.IsExported() {
+			continue
+		}
+		if !seen[f.Name] {
+			fields = append(fields, name+"."+f.Name)
+		}
+	}
+	return fields
+}
+func TestACMTagUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(acm.Tag{}), true, "_", "Key", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestACMTags(t *testing.T) {
+	src := tags{tag{}, tag{}}
+	dst := src.ACMTags()
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
+func TestDataSyncTag(t *testing.T) {
+	src := new(tag)
+	src.Key = "src.Key 1"
+	src.Value = "src.Value 2"
+	dst := src.DataSyncTag()
+	if dst.Key == nil || *dst.Key != "src.Key 1" {
+		t.Errorf("dst.Key does not point to %#v", "src.Key 1")
+	}
+	if dst.Value == nil || *dst.Value != "src.Value 2" {
+		t.Errorf("dst.Value does not point to %#v", "src.Value 2")
+	}
+}
+func TestDataSyncTagUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(datasync.TagListEntry{}), true, "_", "Key", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestDataSyncTags(t *testing.T) {
+	src := tags{tag{}, tag{}}
+	dst := src.DataSyncTags()
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
+func TestDirectoryServiceTag(t *testing.T) {
+	src := new(tag)
+	src.Key = "src.Key 1"
+	src.Value = "src.Value 2"
+	dst := src.DirectoryServiceTag()
+	if dst.Key == nil || *dst.Key != "src.Key 1" {
+		t.Errorf("dst.Key does not point to %#v", "src.Key 1")
+	}
+	if dst.Value == nil || *dst.Value != "src.Value 2" {
+		t.Errorf("dst.Value does not point to %#v", "src.Value 2")
+	}
+}
+func TestDirectoryServiceTagUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(directoryservice.Tag{}), true, "_", "Key", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestDirectoryServiceTags(t *testing.T) {
+	src := tags{tag{}, tag{}}
+	dst := src.DirectoryServiceTags()
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
+func TestEC2Tag(t *testing.T) {
+	src := new(tag)
+	src.Key = "src.Key 1"
+	src.Value = "src.Value 2"
+	dst := src.EC2Tag()
+	if dst.Key == nil || *dst.Key != "src.Key 1" {
+		t.Errorf("dst.Key does not point to %#v", "src.Key 1")
+	}
+	if dst.Value == nil || *dst.Value != "src.Value 2" {
+		t.Errorf("dst.Value does not point to %#v", "src.Value 2")
+	}
+}
+func TestEC2TagUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ec2.Tag{}), true, "_", "Key", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestEC2Tags(t *testing.T) {
+	src := tags{tag{}, tag{}}
+	dst := src.EC2Tags()
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
+func TestELBV2Tag(t *testing.T) {
+	src := new(tag)
+	src.Key = "src.Key 1"
+	src.Value = "src.Value 2"
+	dst := src.ELBV2Tag()
+	if dst.Key == nil || *dst.Key != "src.Key 1" {
+		t.Errorf("dst.Key does not point to %#v", "src.Key 1")
+	}
+	if dst.Value == nil || *dst.Value != "src.Value 2" {
+		t.Errorf("dst.Value does not point to %#v", "src.Value 2")
+	}
+}
+func TestELBV2TagUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(elbv2.Tag{}), true, "_", "Key", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestELBV2Tags(t *testing.T) {
+	src := tags{tag{}, tag{}}
+	dst := src.ELBV2Tags()
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
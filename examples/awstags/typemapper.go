@@ -1,4 +1,4 @@
-// +build typemapper
+//go:build typemapper
 
 package awstags
 
@@ -21,7 +21,6 @@ func (src *tag) ACMTag() *acm.Tag {
 func (src tags) ACMTags() []*acm.Tag {
 	var dst []*acm.Tag
 	typemapper.CreateMap(src, dst)
-	typemapper.MapWith(src[0].ACMTag)
 	return dst
 }
 
@@ -34,7 +33,6 @@ func (src *tag) DataSyncTag() *datasync.TagListEntry {
 func (src tags) DataSyncTags() []*datasync.TagListEntry {
 	var dst []*datasync.TagListEntry
 	typemapper.CreateMap(src, dst)
-	typemapper.MapWith(src[0].DataSyncTag)
 	return dst
 }
 
@@ -47,7 +45,6 @@ func (src *tag) DirectoryServiceTag() *directoryservice.Tag {
 func (src tags) DirectoryServiceTags() []*directoryservice.Tag {
 	var dst []*directoryservice.Tag
 	typemapper.CreateMap(src, dst)
-	typemapper.MapWith(src[0].DirectoryServiceTag)
 	return dst
 }
 
@@ -60,7 +57,6 @@ func (src *tag) EC2Tag() *ec2.Tag {
 func (src tags) EC2Tags() []*ec2.Tag {
 	var dst []*ec2.Tag
 	typemapper.CreateMap(src, dst)
-	typemapper.MapWith(src[0].EC2Tag)
 	return dst
 }
 
@@ -73,6 +69,5 @@ func (src *tag) ELBV2Tag() *elbv2.Tag {
 func (src tags) ELBV2Tags() []*elbv2.Tag {
 	var dst []*elbv2.Tag
 	typemapper.CreateMap(src, dst)
-	typemapper.MapWith(src[0].ELBV2Tag)
 	return dst
 }
@@ -0,0 +1,10 @@
+package consul
+
+// Registration is the subset of a service registration this example cares
+// about.
+type Registration struct {
+	ID      string
+	Service string
+	Tags    []string
+	Port    int
+}
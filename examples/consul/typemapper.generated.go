@@ -1,11 +1,22 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package consul
 
 import structs "github.com/hashicorp/consul/agent/structs"
 
+func RegistrationToNodeService(src *Registration, dst *structs.NodeService) {
+	if dst == nil {
+		return
+	}
+	dst.ID = src.ID
+	dst.Service = src.Service
+	dst.Tags = src.Tags
+	dst.Port = src.Port
+	// ignored "Kind", "Address", "Meta", "Weights", "EnableTagOverride", "ProxyDestination", "Proxy", "Connect", "LocallyRegisteredAsSidecar", "RaftIndex"
+	return
+}
 func ServiceNodeToNodeService(src *structs.ServiceNode, dst *structs.NodeService) {
 	if dst == nil {
 		return
@@ -23,5 +34,6 @@ func ServiceNodeToNodeService(src *structs.ServiceNode, dst *structs.NodeService
 	dst.Proxy = src.ServiceProxy
 	dst.Connect = src.ServiceConnect
 	dst.RaftIndex = src.RaftIndex
+	// ignored "LocallyRegisteredAsSidecar"
 	return
 }
@@ -1,9 +1,112 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package consul
 
-import "testing"
+import (
+	structs "github.com/hashicorp/consul/agent/structs"
+	"reflect"
+	"strings"
+	"testing"
+)
 
-func TestServiceNodeToNodeService(t *testing.T) {}
+func TestRegistrationToNodeService(t *testing.T) {
+	src := new(Registration)
+	src.ID = "src.ID 1"
+	src.Service = "src.Service 2"
+	src.Tags = []string{"src.Tags 3"}
+	src.Port = 4
+	dst := new(structs.NodeService)
+	RegistrationToNodeService(src, dst)
+	if dst.ID != "src.ID 1" {
+		t.Errorf("dst.ID = %#v, want %#v", dst.ID, "src.ID 1")
+	}
+	if dst.Service != "src.Service 2" {
+		t.Errorf("dst.Service = %#v, want %#v", dst.Service, "src.Service 2")
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"src.Tags 3"}) {
+		t.Errorf("dst.Tags = %#v, want %#v", dst.Tags, []string{"src.Tags 3"})
+	}
+	if dst.Port != 4 {
+		t.Errorf("dst.Port = %#v, want %#v", dst.Port, 4)
+	}
+}
+
+// staleFields returns the fields of ty that are not known, prefixed with name.
+func staleFields(name string, ty reflect.Type, exported bool, known ...string) []string {
+	seen := map[string]bool{}
+	for _, k := range known {
+		seen[k] = true
+	}
+	fields := []string{}
+	for i := 0; i < ty.NumField(); i++ {
+		f := ty.Field(i)
+		if exported && !f.IsExported() {
+			continue
+		}
+		if !seen[f.Name] {
+			fields = append(fields, name+"."+f.Name)
+		}
+	}
+	return fields
+}
+func TestRegistrationToNodeServiceUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(structs.NodeService{}), true, "Kind", "ID", "Service", "Tags", "Address", "Meta", "Port", "Weights", "EnableTagOverride", "ProxyDestination", "Proxy", "Connect", "LocallyRegisteredAsSidecar", "RaftIndex")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestServiceNodeToNodeService(t *testing.T) {
+	src := new(structs.ServiceNode)
+	src.ID = "src.ID 1"
+	src.Node = "src.Node 2"
+	src.Address = "src.Address 3"
+	src.Datacenter = "src.Datacenter 4"
+	src.TaggedAddresses = map[string]string{"src.TaggedAddresses 5": "src.TaggedAddresses 5"}
+	src.NodeMeta = map[string]string{"src.NodeMeta 6": "src.NodeMeta 6"}
+	src.ServiceKind = "src.ServiceKind 7"
+	src.ServiceID = "src.ServiceID 8"
+	src.ServiceName = "src.ServiceName 9"
+	src.ServiceTags = []string{"src.ServiceTags 10"}
+	src.ServiceAddress = "src.ServiceAddress 11"
+	src.ServiceMeta = map[string]string{"src.ServiceMeta 12": "src.ServiceMeta 12"}
+	src.ServicePort = 13
+	src.ServiceEnableTagOverride = false
+	src.ServiceProxyDestination = "src.ServiceProxyDestination 15"
+	dst := new(structs.NodeService)
+	ServiceNodeToNodeService(src, dst)
+	if dst.Kind != "src.ServiceKind 7" {
+		t.Errorf("dst.Kind = %#v, want %#v", dst.Kind, "src.ServiceKind 7")
+	}
+	if dst.ID != "src.ID 1" {
+		t.Errorf("dst.ID = %#v, want %#v", dst.ID, "src.ID 1")
+	}
+	if dst.Service != "src.ServiceName 9" {
+		t.Errorf("dst.Service = %#v, want %#v", dst.Service, "src.ServiceName 9")
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"src.ServiceTags 10"}) {
+		t.Errorf("dst.Tags = %#v, want %#v", dst.Tags, []string{"src.ServiceTags 10"})
+	}
+	if dst.Address != "src.Address 3" {
+		t.Errorf("dst.Address = %#v, want %#v", dst.Address, "src.Address 3")
+	}
+	if !reflect.DeepEqual(dst.Meta, map[string]string{"src.ServiceMeta 12": "src.ServiceMeta 12"}) {
+		t.Errorf("dst.Meta = %#v, want %#v", dst.Meta, map[string]string{"src.ServiceMeta 12": "src.ServiceMeta 12"})
+	}
+	if dst.Port != 13 {
+		t.Errorf("dst.Port = %#v, want %#v", dst.Port, 13)
+	}
+	if dst.EnableTagOverride {
+		t.Errorf("dst.EnableTagOverride = %#v, want %#v", dst.EnableTagOverride, false)
+	}
+	if dst.ProxyDestination != "src.ServiceProxyDestination 15" {
+		t.Errorf("dst.ProxyDestination = %#v, want %#v", dst.ProxyDestination, "src.ServiceProxyDestination 15")
+	}
+}
+func TestServiceNodeToNodeServiceUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(structs.NodeService{}), true, "Kind", "ID", "Service", "Tags", "Address", "Meta", "Port", "Weights", "EnableTagOverride", "ProxyDestination", "Proxy", "Connect", "LocallyRegisteredAsSidecar", "RaftIndex")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -1,4 +1,4 @@
-// +build typemapper
+//go:build typemapper
 
 package consul
 
@@ -19,4 +19,11 @@ func ServiceNodeToNodeService(src *structs.ServiceNode, dst *structs.NodeService
 	return
 }
 
+// RegistrationToNodeService only fills the handful of fields a registration
+// sets, the rest of structs.NodeService is left alone.
+func RegistrationToNodeService(src *Registration, dst *structs.NodeService) {
+	typemapper.CreateMap(src, dst)
+	typemapper.OnlyFields(dst.ID, dst.Service, dst.Tags, dst.Port)
+}
+
 // https://github.com/hashicorp/consul/blob/5457bca10c1f8a2ac0e338fdc06c95fd5cff49c3/agent/structs/structs.go#L893-L930
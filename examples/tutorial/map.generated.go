@@ -1,12 +1,13 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package main
 
 func MapFooToBar(src Foo) Bar {
 	dst := Bar{}
 	dst.FieldOne = src.FieldOne
-	// no match for "Field2"
+	// no match for "Field2", did you mean:
+	// typemapper.MapField(src.FieldTwo, dst.Field2)
 	return dst
 }
@@ -1,11 +1,40 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package main
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestMapFooToBar(t *testing.T) {
-	t.Fatal("no mapping for: [Field2]")
+	t.Fatal("no mapping for: [Field2], did you mean: typemapper.MapField(src.FieldTwo, dst.Field2)")
+}
+
+// staleFields returns the fields of ty that are not known, prefixed with name.
+func staleFields(name string, ty reflect.Type, exported bool, known ...string) []string {
+	seen := map[string]bool{}
+	for _, k := range known {
+		seen[k] = true
+	}
+	fields := []string{}
+	for i := 0; i < ty.NumField(); i++ {
+		f := ty.Field(i)
+		if exported && !f.IsExported() {
+			continue
+		}
+		if !seen[f.Name] {
+			fields = append(fields, name+"."+f.Name)
+		}
+	}
+	return fields
+}
+func TestMapFooToBarUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(Bar{}), false, "FieldOne", "Field2")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
 }
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/paultyng/go-typemapper/mapper"
+)
+
+// Diagnostic describes a possible problem with a generated mapping that
+// does not stop generation.
+type Diagnostic struct {
+	Pos     token.Position
+	Mapping string
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Mapping, d.Message)
+}
+
+// Diagnostics returns the diagnostics reported while generating mappings.
+func (g *Generator) Diagnostics() []Diagnostic {
+	return g.diagnostics
+}
+
+// StrictCopy copies values recursively in all mappings, as if they used
+// DeepCopy, so the destination never shares memory with the source.
+func (g *Generator) StrictCopy() *Generator {
+	g.strictCopy = true
+	return g
+}
+
+func (g *Generator) diagnose(mf *mappingFunc, format string, args ...interface{}) {
+	g.diagnostics = append(g.diagnostics, Diagnostic{
+		Pos:     g.ssapkg.Prog.Fset.Position(mf.fn.Pos()),
+		Mapping: mf.name,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// diagnoseAliasing reports field assignments where the destination shares
+// memory with the source, so modifying one after mapping modifies the other.
+func (g *Generator) diagnoseAliasing(mf *mappingFunc, srcName, dstName string, p mapper.FieldPair) {
+	srcField := srcName + "." + p.Source.Path()
+	if p.Value {
+		srcField = p.Source.Path()
+	}
+	dstField := dstName + "." + p.Destination.Path()
+
+	srcType, dstType := p.Source.Type(), p.Destination.Type()
+	srcSlice, dstSlice := sliceType(srcType), sliceType(dstType)
+	if srcSlice != nil && dstSlice != nil && !types.AssignableTo(srcType, dstType) {
+		// mapped element by element
+		if kind := g.aliasing(mf, srcSlice.Elem(), dstSlice.Elem()); kind != "" {
+			g.diagnose(mf, "elements of %s share %s with elements of %s", dstField, kind, srcField)
+		}
+		return
+	}
+
+	if isPointer(dstType) && !isPointer(srcType) && g.throughPointer(mf, p) &&
+		g.deepCopyType(mf, srcType, dstType) == nil && !g.converted(mf, srcType, dstType) {
+		g.diagnose(mf, "%s points to %s", dstField, srcField)
+		return
+	}
+
+	if kind := g.aliasing(mf, srcType, dstType); kind != "" {
+		g.diagnose(mf, "%s shares %s with %s", dstField, kind, srcField)
+	}
+}
+
+// diagnoseElementAliasing reports slice mappings where the destination
+// elements share memory with the source elements.
+func (g *Generator) diagnoseElementAliasing(mf *mappingFunc, srcName, dstName string) {
+	srcElem, dstElem := unwrapSlice(mf.srcType).Elem(), unwrapSlice(mf.dstType).Elem()
+	if kind := g.aliasing(mf, srcElem, dstElem); kind != "" {
+		g.diagnose(mf, "elements of %s share %s with elements of %s", dstName, kind, srcName)
+	}
+}
+
+// throughPointer reports if the source field is selected through a pointer,
+// so its address points into memory shared with the caller.
+func (g *Generator) throughPointer(mf *mappingFunc, p mapper.FieldPair) bool {
+	if isPointer(mf.sourceType(p.SourceIndex)) {
+		return true
+	}
+	for _, e := range p.Source.Parents() {
+		if isPointer(e.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// converted reports if srcType is converted to dstType to a new value, by a
+// mapping function or by wrapping or unwrapping an optional value.
+func (g *Generator) converted(mf *mappingFunc, srcType, dstType types.Type) bool {
+	if mw, err := g.converter(mf, srcType, dstType); mw != nil || err != nil {
+		return true
+	}
+	return g.wrapperConvertible(mf, srcType, dstType)
+}
+
+// aliasing returns the kind of memory shared when assigning srcType to
+// dstType, or an empty string if none is.
+func (g *Generator) aliasing(mf *mappingFunc, srcType, dstType types.Type) string {
+	if g.deepCopyType(mf, srcType, dstType) != nil || g.converted(mf, srcType, dstType) {
+		return ""
+	}
+
+	ty := srcType
+	if isPointer(srcType) && !isPointer(dstType) {
+		// dereferenced when assigned
+		ty = unwrapPointer(srcType)
+	}
+	if !g.hasReferences(ty) {
+		return ""
+	}
+
+	switch ty.Underlying().(type) {
+	case *types.Pointer:
+		return "a pointer"
+	case *types.Slice:
+		return "a slice"
+	case *types.Map:
+		return "a map"
+	}
+	return fmt.Sprintf("references in %s", g.typeString(ty))
+}
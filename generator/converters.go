@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// converter returns the mapping function used to convert srcType to dstType
+// in the mapping mf. MapWith functions take precedence, then a unique
+// matching function from the packages of UseConverters, otherwise types that
+// cannot be converted directly use a unique matching mapping function from
+// the package.
+func (g *Generator) converter(mf *mappingFunc, srcType, dstType types.Type) (*mappingFunc, error) {
+	if mw := mf.MapWith(g.cache).callableFrom(mf).converter(srcType, dstType); mw != nil {
+		return mw, nil
+	}
+
+	switch candidates := mf.converters.callableFrom(mf).converters(srcType, dstType); len(candidates) {
+	case 0:
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, errors.Errorf(
+			"ambiguous converters for %s to %s in %s: %s, use MapWith to choose one",
+			g.typeString(srcType), g.typeString(dstType), mf.name, candidates.names(", "),
+		)
+	}
+
+	if mf.disableAutoMapWith || types.AssignableTo(unwrapType(srcType), unwrapType(dstType)) {
+		return nil, nil
+	}
+
+	candidates := g.autoMapWith(mf).converters(srcType, dstType)
+	switch len(candidates) {
+	case 0:
+		if !mf.transitive {
+			return nil, nil
+		}
+		chain, err := g.chain(mf, srcType, dstType)
+		if err != nil || len(chain) == 0 {
+			return nil, err
+		}
+		return &mappingFunc{
+			name:           chain.names(" -> "),
+			srcType:        srcType,
+			dstType:        dstType,
+			dstConstructed: true,
+			dstReturned:    true,
+			chain:          chain,
+		}, nil
+	case 1:
+		return candidates[0], nil
+	}
+
+	return nil, errors.Errorf(
+		"ambiguous mapping functions for %s to %s in %s: %s, use MapWith to choose one",
+		g.typeString(srcType), g.typeString(dstType), mf.name, candidates.names(", "),
+	)
+}
+
+// chain returns the shortest sequence of mapping functions converting srcType
+// to dstType through intermediate types, for mappings using MapTransitively.
+func (g *Generator) chain(mf *mappingFunc, srcType, dstType types.Type) (mappingCache, error) {
+	candidates := append(mf.MapWith(g.cache), mf.converters...).callableFrom(mf)
+	if !mf.disableAutoMapWith {
+		candidates = append(candidates, g.autoMapWith(mf)...)
+	}
+
+	type step struct {
+		ty    types.Type
+		chain mappingCache
+	}
+
+	visited := []types.Type{srcType}
+	frontier := []step{{ty: srcType}}
+	for len(frontier) > 0 {
+		found := []mappingCache{}
+		next := []step{}
+		for _, s := range frontier {
+			for _, c := range candidates {
+				if !c.accepts(s.ty) {
+					continue
+				}
+				chain := append(append(mappingCache{}, s.chain...), c)
+				if c.returns(dstType) {
+					found = append(found, chain)
+					continue
+				}
+				if containsType(visited, c.dstType) {
+					continue
+				}
+				visited = append(visited, c.dstType)
+				next = append(next, step{ty: c.dstType, chain: chain})
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			frontier = next
+			continue
+		case 1:
+			return found[0], nil
+		}
+
+		chains := make([]string, 0, len(found))
+		for _, f := range found {
+			chains = append(chains, f.names(" -> "))
+		}
+		return nil, errors.Errorf(
+			"ambiguous mapping chains for %s to %s in %s: %s, use MapWith to choose one",
+			g.typeString(srcType), g.typeString(dstType), mf.name, strings.Join(chains, ", "),
+		)
+	}
+	return nil, nil
+}
+
+func containsType(tys []types.Type, ty types.Type) bool {
+	for _, t := range tys {
+		if types.Identical(t, ty) {
+			return true
+		}
+	}
+	return false
+}
+
+// autoMapWith returns the mapping functions of the package that can be called
+// as converters from the mapping mf.
+func (g *Generator) autoMapWith(mf *mappingFunc) mappingCache {
+	mc := mappingCache{}
+	for _, c := range g.cache {
+		if c == mf || c.errReturned || !c.dstConstructed || c.fn.Signature.TypeParams().Len() > 0 {
+			continue
+		}
+		mc = append(mc, c)
+	}
+	return mc.callableFrom(mf)
+}
+
+// convertible reports if srcType can be converted to dstType with a mapping
+// function, either directly or element by element for slices.
+func (g *Generator) convertible(mf *mappingFunc, srcType, dstType types.Type) bool {
+	if mw, err := g.converter(mf, srcType, dstType); mw != nil || err != nil {
+		// ambiguous mapping functions are reported when generating the conversion
+		return true
+	}
+
+	srcSlice, dstSlice := sliceType(srcType), sliceType(dstType)
+	if srcSlice == nil || dstSlice == nil {
+		return false
+	}
+	if types.AssignableTo(srcSlice.Elem(), dstSlice.Elem()) {
+		return true
+	}
+	return g.convertible(mf, srcSlice.Elem(), dstSlice.Elem())
+}
+
+func (g *Generator) typeString(t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(g.ssapkg.Pkg))
+}
@@ -0,0 +1,328 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// seenName is the variable tracking copied pointers, so shared and cyclic
+// references are copied once.
+const seenName = "seen"
+
+// deepCopyType returns the type to copy recursively when assigning srcType
+// to dstType in a DeepCopy mapping, or nil if a plain assignment is enough.
+func (g *Generator) deepCopyType(mf *mappingFunc, srcType, dstType types.Type) types.Type {
+	if !mf.deepCopy && !g.strictCopy {
+		return nil
+	}
+
+	var ty types.Type
+	switch {
+	case types.AssignableTo(srcType, dstType):
+		ty = dstType
+	case isPointer(dstType) && types.AssignableTo(srcType, unwrapPointer(dstType)):
+		ty = dstType
+	case isPointer(srcType) && types.AssignableTo(unwrapPointer(srcType), dstType):
+		ty = dstType
+	default:
+		return nil
+	}
+
+	if !g.hasReferences(ty) {
+		return nil
+	}
+	return ty
+}
+
+// deepCopySeen declares the variable tracking copied pointers for the
+// mapping, the source is tracked as the destination when both are pointers
+// to the same type so references back to it are not copied again.
+func (g *Generator) deepCopySeen(mf *mappingFunc, srcName, dstName string) *Statement {
+	seen := Dict{}
+	if isPointer(mf.srcType) && types.Identical(mf.srcType, mf.dstType) {
+		seen[Id(srcName)] = Id(dstName)
+	}
+	return Id(seenName).Op(":=").Map(Any()).Any().Values(seen)
+}
+
+// deepCopySeenUsed reports if copying srcType to dstType in the mapping
+// uses the variable tracking copied pointers, so it must be declared.
+func (g *Generator) deepCopySeenUsed(mf *mappingFunc, srcType, dstType types.Type) bool {
+	ty := g.deepCopyType(mf, srcType, dstType)
+	return ty != nil && g.deepCopyUsesSeen(mf, ty, map[types.Type]bool{})
+}
+
+// deepCopyUsesSeen reports if the copy of ty tracks pointers, only the copy
+// functions reaching pointers take the seen variable.
+func (g *Generator) deepCopyUsesSeen(mf *mappingFunc, ty types.Type, visited map[types.Type]bool) bool {
+	if g.deepCopyConverters(mf).converter(ty, ty) != nil || !g.hasReferences(ty) {
+		return false
+	}
+	if named, ok := ty.(*types.Named); ok {
+		if visited[named] {
+			return false
+		}
+		visited[named] = true
+	}
+	switch u := ty.Underlying().(type) {
+	case *types.Pointer:
+		return true
+	case *types.Slice:
+		return g.deepCopyUsesSeen(mf, u.Elem(), visited)
+	case *types.Array:
+		return g.deepCopyUsesSeen(mf, u.Elem(), visited)
+	case *types.Map:
+		return g.deepCopyUsesSeen(mf, u.Elem(), visited)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); g.copiesField(f) && g.deepCopyUsesSeen(mf, f.Type(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deepCopyAssignment assigns a recursive copy of srcExpr to dstExpr, it
+// returns nil if the types are not copied recursively.
+func (g *Generator) deepCopyAssignment(mf *mappingFunc, srcExpr, dstExpr *Statement, srcType, dstType types.Type) ([]Code, error) {
+	copyExpr, err := g.deepCopyValue(mf, srcExpr, srcType, dstType)
+	if copyExpr == nil || err != nil {
+		return nil, err
+	}
+	return []Code{
+		dstExpr.Op("=").Add(copyExpr),
+	}, nil
+}
+
+// deepCopyValue returns a recursive copy of srcExpr for dstType, it returns
+// nil if the types are not copied recursively.
+func (g *Generator) deepCopyValue(mf *mappingFunc, srcExpr *Statement, srcType, dstType types.Type) (*Statement, error) {
+	ty := g.deepCopyType(mf, srcType, dstType)
+	if ty == nil {
+		return nil, nil
+	}
+
+	switch {
+	case isPointer(dstType) && !isPointer(srcType):
+		srcExpr = Op("&").Add(srcExpr)
+	case isPointer(srcType) && !isPointer(dstType):
+		srcExpr = Op("*").Add(srcExpr)
+	}
+	copyExpr, err := g.deepCopyExpr(mf, srcExpr, ty)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return copyExpr, nil
+}
+
+// hasReferences reports if values of ty can share memory when assigned.
+// Interfaces, functions and channels are not copied, neither are named
+// pointer types.
+func (g *Generator) hasReferences(ty types.Type) bool {
+	switch ty := ty.(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	case *types.Array:
+		return g.hasReferences(ty.Elem())
+	case *types.Named:
+		switch u := ty.Underlying().(type) {
+		case *types.Pointer:
+			return false
+		case *types.Struct:
+			for i := 0; i < u.NumFields(); i++ {
+				if g.copiesField(u.Field(i)) {
+					return true
+				}
+			}
+			return false
+		}
+		return g.hasReferences(ty.Underlying())
+	case *types.Struct:
+		for i := 0; i < ty.NumFields(); i++ {
+			if g.copiesField(ty.Field(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copiesField reports if the struct field is copied recursively, fields
+// unexported from other packages are left as is.
+func (g *Generator) copiesField(f *types.Var) bool {
+	if !f.Exported() && f.Pkg() != g.ssapkg.Pkg {
+		return false
+	}
+	return g.hasReferences(f.Type())
+}
+
+// deepCopyConverters returns the MapWith functions of the mapping used to
+// copy a type, they must return the type they take without other arguments.
+func (g *Generator) deepCopyConverters(mf *mappingFunc) mappingCache {
+	mc := mappingCache{}
+	for _, mw := range mf.MapWith(g.cache) {
+		if len(mw.chain) > 0 || !types.Identical(mw.srcType, mw.dstType) {
+			continue
+		}
+		if len(mw.params) > 1 || (len(mw.params) == 1 && mw.srcParam != 0) {
+			continue
+		}
+		mc = append(mc, mw)
+	}
+	return mc
+}
+
+// deepCopyExpr returns an expression copying expr of type ty recursively.
+func (g *Generator) deepCopyExpr(mf *mappingFunc, expr *Statement, ty types.Type) (*Statement, error) {
+	copiers := g.deepCopyConverters(mf)
+	if mw := copiers.converter(ty, ty); mw != nil {
+		return g.callMapWith(mf, mw, expr)
+	}
+	if !g.hasReferences(ty) {
+		return expr, nil
+	}
+
+	// copy functions are shared by mappings unless they use their own
+	// MapWith functions
+	prefix := "deepCopy"
+	if len(copiers) > 0 {
+		prefix = strings.ToLower(mf.name[:1]) + mf.name[1:] + "DeepCopy"
+	}
+	name := prefix + g.typeName(ty)
+	if !g.deepCopyFuncs[name] {
+		g.deepCopyFuncs[name] = true
+		err := g.generateDeepCopyFunc(mf, name, ty)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if !g.deepCopyUsesSeen(mf, ty, map[types.Type]bool{}) {
+		return Id(name).Params(expr), nil
+	}
+	return Id(name).Params(expr, Id(seenName)), nil
+}
+
+func (g *Generator) generateDeepCopyFunc(mf *mappingFunc, name string, ty types.Type) error {
+	srcName, dstName := defaultSrcName, defaultDstName
+	src, dst := Id(srcName), Id(dstName)
+
+	// copies of nested values are generated before the function
+	var err error
+	copyExpr := func(expr *Statement, ty types.Type) *Statement {
+		if err != nil {
+			return nil
+		}
+		var code *Statement
+		code, err = g.deepCopyExpr(mf, expr, ty)
+		return code
+	}
+
+	body := []Code{}
+	switch u := ty.Underlying().(type) {
+	case *types.Pointer:
+		body = append(body,
+			If(src.Clone().Op("==").Nil()).Block(Return(Nil())),
+			If(List(dst.Clone(), Id("ok")).Op(":=").Id(seenName).Index(src.Clone()), Id("ok")).Block(
+				Return(dst.Clone().Assert(g.genType(ty))),
+			),
+			dst.Clone().Op(":=").New(g.genType(u.Elem())),
+			Id(seenName).Index(src.Clone()).Op("=").Add(dst.Clone()),
+			Op("*").Add(dst.Clone()).Op("=").Add(copyExpr(Op("*").Add(src.Clone()), u.Elem())),
+		)
+	case *types.Slice:
+		body = append(body,
+			If(src.Clone().Op("==").Nil()).Block(Return(Nil())),
+			dst.Clone().Op(":=").Make(g.genType(ty), Len(src.Clone())),
+		)
+		if g.hasReferences(u.Elem()) || g.deepCopyConverters(mf).converter(u.Elem(), u.Elem()) != nil {
+			body = append(body, For(List(Id("i"), Id("x")).Op(":=").Range().Add(src.Clone())).Block(
+				dst.Clone().Index(Id("i")).Op("=").Add(copyExpr(Id("x"), u.Elem())),
+			))
+		} else {
+			body = append(body, Copy(dst.Clone(), src.Clone()))
+		}
+	case *types.Array:
+		body = append(body,
+			dst.Clone().Op(":=").Add(src.Clone()),
+			For(List(Id("i"), Id("x")).Op(":=").Range().Add(src.Clone())).Block(
+				dst.Clone().Index(Id("i")).Op("=").Add(copyExpr(Id("x"), u.Elem())),
+			),
+		)
+	case *types.Map:
+		body = append(body,
+			If(src.Clone().Op("==").Nil()).Block(Return(Nil())),
+			dst.Clone().Op(":=").Make(g.genType(ty), Len(src.Clone())),
+			For(List(Id("k"), Id("v")).Op(":=").Range().Add(src.Clone())).Block(
+				// keys are compared by value, so they are not copied
+				dst.Clone().Index(Id("k")).Op("=").Add(copyExpr(Id("v"), u.Elem())),
+			),
+		)
+	case *types.Struct:
+		body = append(body, dst.Clone().Op(":=").Add(src.Clone()))
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if !g.copiesField(f) {
+				continue
+			}
+			body = append(body,
+				dst.Clone().Dot(f.Name()).Op("=").Add(copyExpr(src.Clone().Dot(f.Name()), f.Type())),
+			)
+		}
+	}
+	body = append(body, Return(dst.Clone()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	params := []Code{Id(srcName).Add(g.genType(ty))}
+	if g.deepCopyUsesSeen(mf, ty, map[types.Type]bool{}) {
+		params = append(params, Id(seenName).Map(Any()).Any())
+	}
+	g.file(mf.fileName).Func().Id(name).Params(params...).Add(g.genType(ty)).Block(body...)
+	return nil
+}
+
+// typeName returns an identifier fragment for ty, used to name generated
+// functions.
+func (g *Generator) typeName(ty types.Type) string {
+	switch ty := ty.(type) {
+	case *types.Pointer:
+		return "Ptr" + g.typeName(ty.Elem())
+	case *types.Slice:
+		return "Slice" + g.typeName(ty.Elem())
+	case *types.Array:
+		return fmt.Sprintf("Array%d%s", ty.Len(), g.typeName(ty.Elem()))
+	case *types.Map:
+		return "Map" + g.typeName(ty.Key()) + g.typeName(ty.Elem())
+	case *types.Named:
+		name := ty.Obj().Name()
+		if pkg := ty.Obj().Pkg(); pkg != nil && pkg != g.ssapkg.Pkg {
+			name = exportedName(pkg.Name()) + exportedName(name)
+		}
+		for i := 0; i < ty.TypeArgs().Len(); i++ {
+			name += g.typeName(ty.TypeArgs().At(i))
+		}
+		return exportedName(name)
+	case *types.Basic:
+		return exportedName(ty.Name())
+	case *types.Struct:
+		name := "Struct"
+		for i := 0; i < ty.NumFields(); i++ {
+			name += exportedName(ty.Field(i).Name()) + g.typeName(ty.Field(i).Type())
+		}
+		return name
+	}
+	return "Value"
+}
+
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/ssa"
+)
+
+// sourceValue is a value computed from the parameters of a mapping function,
+// for example `src.FullName()` passed to MapField.
+type sourceValue struct {
+	code *Statement
+	ty   types.Type
+	// uses are the source fields selected in the expression
+	uses []sourceField
+}
+
+// expression re-emits the SSA value v as an expression in terms of the
+// parameters of the function, so it can be used in the generated function.
+func (g *Generator) expression(v ssa.Value) (*Statement, error) {
+	switch v := v.(type) {
+	case *ssa.Parameter:
+		return Id(v.Name()), nil
+	case *ssa.Alloc:
+		// parameters are stored in allocations when their address is taken
+		if p := allocParam(v); p != nil {
+			return Id(p.Name()), nil
+		}
+	case *ssa.Const:
+		if v.Value == nil {
+			return Nil(), nil
+		}
+		if v.Value.Kind() == constant.Float {
+			// exact floats are fractions, such as 1/2, which would be
+			// integer divisions
+			f, _ := constant.Float64Val(v.Value)
+			if f < 0 {
+				return Parens(Lit(f)), nil
+			}
+			return Lit(f), nil
+		}
+		return Op(v.Value.ExactString()), nil
+	case *ssa.MakeInterface:
+		return g.expression(v.X)
+	case *ssa.ChangeType:
+		return g.conversion(v.Type(), v.X)
+	case *ssa.Convert:
+		return g.conversion(v.Type(), v.X)
+	case *ssa.UnOp:
+		x, err := g.expression(v.X)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if v.Op != token.MUL {
+			return Op(v.Op.String()).Add(x), nil
+		}
+		switch v.X.(type) {
+		case *ssa.Alloc, *ssa.FieldAddr, *ssa.IndexAddr:
+			// loads of addressable values
+			return x, nil
+		}
+		return Parens(Op("*").Add(x)), nil
+	case *ssa.BinOp:
+		x, err := g.operand(v.X)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		y, err := g.operand(v.Y)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return x.Op(v.Op.String()).Add(y), nil
+	case *ssa.FieldAddr:
+		return g.selector(v.X, v.Field)
+	case *ssa.Field:
+		return g.selector(v.X, v.Field)
+	case *ssa.IndexAddr:
+		return g.index(v.X, v.Index)
+	case *ssa.Index:
+		return g.index(v.X, v.Index)
+	case *ssa.Lookup:
+		if !v.CommaOk {
+			return g.index(v.X, v.Index)
+		}
+	case *ssa.Slice:
+		x, err := g.expression(v.X)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		bounds := []Code{}
+		for _, b := range []ssa.Value{v.Low, v.High} {
+			if b == nil {
+				bounds = append(bounds, Empty())
+				continue
+			}
+			code, err := g.expression(b)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			bounds = append(bounds, code)
+		}
+		return x.Index(bounds...), nil
+	case *ssa.Call:
+		return g.callExpression(v.Common())
+	}
+	return nil, errors.Errorf("unsupported expression %s", v)
+}
+
+// operand returns the expression for an operand of a binary operation.
+func (g *Generator) operand(v ssa.Value) (*Statement, error) {
+	code, err := g.expression(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if _, ok := v.(*ssa.BinOp); ok {
+		return Parens(code), nil
+	}
+	return code, nil
+}
+
+func (g *Generator) conversion(ty types.Type, v ssa.Value) (*Statement, error) {
+	x, err := g.expression(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return g.genType(ty).Params(x), nil
+}
+
+func (g *Generator) selector(x ssa.Value, index int) (*Statement, error) {
+	st, err := structType(x.Type())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	code, err := g.expression(x)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return code.Dot(st.Field(index).Name()), nil
+}
+
+func (g *Generator) index(x, index ssa.Value) (*Statement, error) {
+	code, err := g.expression(x)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	i, err := g.expression(index)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return code.Index(i), nil
+}
+
+func (g *Generator) callExpression(common *ssa.CallCommon) (*Statement, error) {
+	args := common.Args
+	if sig := common.Signature(); sig.Variadic() && len(args) > 0 {
+		// re-emit the elements of the implicit variadic slice
+		last := args[len(args)-1]
+		args = args[:len(args)-1]
+		if c, ok := last.(*ssa.Const); !ok || c.Value != nil {
+			values, err := interfaceSlice(last)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			args = append(args, values...)
+		}
+	}
+
+	codes := make([]Code, 0, len(args))
+	for _, a := range args {
+		code, err := g.expression(a)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		codes = append(codes, code)
+	}
+
+	if common.IsInvoke() {
+		recv, err := g.expression(common.Value)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return recv.Dot(common.Method.Name()).Params(codes...), nil
+	}
+
+	switch fn := common.Value.(type) {
+	case *ssa.Builtin:
+		return Id(fn.Name()).Params(codes...), nil
+	case *ssa.Function:
+		if fn.Signature.Recv() != nil {
+			// method call on the first argument, dereferenced implicitly
+			recv := args[0]
+			if u, ok := recv.(*ssa.UnOp); ok && u.Op == token.MUL {
+				recv = u.X
+			}
+			code, err := g.expression(recv)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return code.Dot(fn.Name()).Params(codes[1:]...), nil
+		}
+		if origin := fn.Origin(); origin != nil {
+			fn = origin
+		}
+		if fn.Parent() != nil || fn.Pkg == nil {
+			break
+		}
+		if fn.Pkg.Pkg != g.ssapkg.Pkg {
+			return Qual(fn.Pkg.Pkg.Path(), fn.Name()).Params(codes...), nil
+		}
+		return Id(fn.Name()).Params(codes...), nil
+	}
+	return nil, errors.Errorf("unsupported call of %s", common.Value)
+}
+
+// selectors returns the values selecting fields in the expression v.
+func selectors(v ssa.Value) []ssa.Value {
+	if path := selectorPath(v); len(path) > 0 {
+		return []ssa.Value{v}
+	}
+	var values []ssa.Value
+	switch v := v.(type) {
+	case *ssa.Alloc:
+		// elements of variadic arguments are stored in an allocation
+		walkReferrers(v, func(inst ssa.Instruction) bool {
+			if store, ok := inst.(*ssa.Store); ok {
+				values = append(values, store.Val)
+			}
+			return true
+		})
+	case ssa.Instruction:
+		for _, op := range v.Operands(nil) {
+			if *op != nil {
+				values = append(values, *op)
+			}
+		}
+	}
+	sels := []ssa.Value{}
+	for _, value := range values {
+		sels = append(sels, selectors(value)...)
+	}
+	return sels
+}
+
+// allocParam returns the parameter stored in the allocation, if any.
+func allocParam(alloc *ssa.Alloc) *ssa.Parameter {
+	refs := alloc.Referrers()
+	if refs == nil {
+		return nil
+	}
+	for _, ref := range *refs {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc {
+			if p, ok := store.Val.(*ssa.Parameter); ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"flag"
+)
+
+// Flags are the command line flags of typemapper configuring the generator.
+type Flags struct {
+	Bench      bool
+	MinAllocs  bool
+	Fuzz       bool
+	Strict     bool
+	StrictCopy bool
+}
+
+// Register defines the flags in fs.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.Bench, "bench", false, "generate benchmarks reporting the allocations of each mapping")
+	fs.BoolVar(&f.MinAllocs, "min-allocs", false, "construct destinations with struct literals and preallocate slices in all mappings")
+	fs.BoolVar(&f.Fuzz, "fuzz", false, "generate fuzz targets for the mappings returning errors")
+	fs.BoolVar(&f.Strict, "strict", false, "fail the generated tests on source fields that are not mapped")
+	fs.BoolVar(&f.StrictCopy, "strict-copy", false, "copy values recursively in all mappings so they never share memory with the source")
+}
+
+// Configure returns g configured with the flags.
+func (f *Flags) Configure(g *Generator) *Generator {
+	if f.Bench {
+		g = g.Bench()
+	}
+	if f.MinAllocs {
+		g = g.MinimizeAllocs()
+	}
+	if f.Fuzz {
+		g = g.Fuzz()
+	}
+	if f.Strict {
+		g = g.Strict()
+	}
+	if f.StrictCopy {
+		g = g.StrictCopy()
+	}
+	return g
+}
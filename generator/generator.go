@@ -11,6 +11,8 @@ import (
 	"github.com/dave/jennifer/jen"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/go-typemapper/mapper"
 )
 
 const BuildTag = "typemapper"
@@ -22,6 +24,26 @@ type Generator struct {
 
 	cache mappingCache
 
+	// deepCopyFuncs are the names of the generated deep copy functions
+	deepCopyFuncs map[string]bool
+	strictCopy    bool
+	// strict fails the generated tests on unused source fields
+	strict bool
+	// fuzz generates fuzz targets for mappings returning errors, fuzzBuilders
+	// are the names of the generated functions building their sources
+	fuzz         bool
+	fuzzBuilders map[string]bool
+	// bench generates benchmarks, minimizeAllocs generates all mappings as
+	// if they used typemapper.MinimizeAllocs
+	bench          bool
+	minimizeAllocs bool
+
+	// staleFieldsFunc is set once the function listing new fields for the
+	// generated up to date tests is generated
+	staleFieldsFunc bool
+
+	diagnostics []Diagnostic
+
 	files map[string]*jen.File
 }
 
@@ -30,12 +52,43 @@ func NewGenerator(ssapkg *ssa.Package, comments ...string) *Generator {
 		pkgName:  ssapkg.Pkg.Name(),
 		ssapkg:   ssapkg,
 		comments: comments,
-		files:    map[string]*jen.File{},
+
+		deepCopyFuncs: map[string]bool{},
+		fuzzBuilders:  map[string]bool{},
+
+		files: map[string]*jen.File{},
 	}
 
 	return g
 }
 
+// Strict fails the generated tests of all mappings on unused source fields,
+// as if they used typemapper.Strict.
+func (g *Generator) Strict() *Generator {
+	g.strict = true
+	return g
+}
+
+// Fuzz generates fuzz targets for the mappings returning errors, checking
+// they do not panic on sources built from the fuzz inputs.
+func (g *Generator) Fuzz() *Generator {
+	g.fuzz = true
+	return g
+}
+
+// Bench generates a benchmark for each mapping, reporting its allocations.
+func (g *Generator) Bench() *Generator {
+	g.bench = true
+	return g
+}
+
+// MinimizeAllocs generates all mappings with fewer allocations, as if they
+// used typemapper.MinimizeAllocs.
+func (g *Generator) MinimizeAllocs() *Generator {
+	g.minimizeAllocs = true
+	return g
+}
+
 func (g *Generator) fileFactory(fileName string) *jen.File {
 	if f, ok := g.files[fileName]; ok {
 		return f
@@ -83,30 +136,27 @@ func (g *Generator) GenerateMappings() error {
 	mfs := mappingCache{}
 
 	prog := g.ssapkg.Prog
+	fns := []*ssa.Function{}
 	for _, mem := range g.ssapkg.Members {
 		switch mem := mem.(type) {
 		case *ssa.Type:
+			if named, ok := mem.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+				// method values are only available for instantiated types,
+				// so use the generic method declarations
+				for i := 0; i < named.NumMethods(); i++ {
+					fns = append(fns, prog.FuncValue(named.Method(i)))
+				}
+				continue
+			}
+
 			for _, ms := range []*types.MethodSet{
 				prog.MethodSets.MethodSet(mem.Type()),
 				prog.MethodSets.MethodSet(types.NewPointer(mem.Type())),
 			} {
 				for i := 0; i < ms.Len(); i++ {
-					sel := ms.At(i)
-					ssaF := prog.MethodValue(sel)
-
-					mf, err := g.parseFunction(ssaF)
-					if err != nil {
-						return errors.WithStack(err)
+					if ssaF := prog.MethodValue(ms.At(i)); ssaF != nil {
+						fns = append(fns, ssaF)
 					}
-					if mf == nil {
-						continue
-					}
-
-					f := prog.Fset.File(ssaF.Pos())
-					_, fileName := filepath.Split(f.Name())
-					mf.fileName = fileName
-
-					mfs = append(mfs, mf)
 				}
 			}
 		case *ssa.Function:
@@ -114,20 +164,24 @@ func (g *Generator) GenerateMappings() error {
 				continue
 			}
 
-			mf, err := g.parseFunction(mem)
-			if err != nil {
-				return errors.WithStack(err)
-			}
-			if mf == nil {
-				continue
-			}
-
-			f := prog.Fset.File(mem.Pos())
-			_, fileName := filepath.Split(f.Name())
-			mf.fileName = fileName
+			fns = append(fns, mem)
+		}
+	}
 
-			mfs = append(mfs, mf)
+	for _, fn := range fns {
+		mf, err := g.parseFunction(fn)
+		if err != nil {
+			return errors.WithStack(err)
 		}
+		if mf == nil {
+			continue
+		}
+
+		f := prog.Fset.File(fn.Pos())
+		_, fileName := filepath.Split(f.Name())
+		mf.fileName = fileName
+
+		mfs = append(mfs, mf)
 	}
 
 	g.cache = mfs
@@ -150,8 +204,49 @@ func (g *Generator) GenerateMappings() error {
 	return nil
 }
 
+// MapConfiguration returns the fields matched by the struct mapping with the
+// name, after GenerateMappings.
+func (g *Generator) MapConfiguration(name string) (mapper.MapConfiguration, error) {
+	var found *mappingFunc
+	for _, mf := range g.cache {
+		if mf.name != name {
+			continue
+		}
+		if found != nil {
+			return mapper.MapConfiguration{}, errors.Errorf("several mappings named %s", name)
+		}
+		found = mf
+	}
+	if found == nil {
+		return mapper.MapConfiguration{}, errors.Errorf("unable to find mapping %s", name)
+	}
+	if !found.StructMapping() {
+		return mapper.MapConfiguration{}, errors.Errorf("%s is not a struct mapping", name)
+	}
+	return g.mapConfiguration(found)
+}
+
 func (g *Generator) generateMappings() error {
 	for _, mf := range g.cache {
+		if mf.transitive {
+			dstType := mf.dstType
+			if !mf.dstConstructed {
+				// destination parameters are assigned through the pointer
+				dstType = unwrapPointer(dstType)
+			}
+			chain, err := g.chain(mf, mf.srcType, dstType)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if len(chain) > 1 {
+				err = g.generateComposedMapping(mf, chain)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				continue
+			}
+		}
+
 		// not structs, so do some alternative mapping
 		switch {
 		default:
@@ -168,5 +263,13 @@ func (g *Generator) generateMappings() error {
 			}
 		}
 	}
+
+	err := g.generateRoundTrips()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if g.fuzz {
+		g.generateFuzzTargets()
+	}
 	return nil
 }
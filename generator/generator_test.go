@@ -1,112 +1,177 @@
-package generator
+package generator_test
 
 import (
 	"bytes"
 	"flag"
-	"fmt"
-	"go/types"
 	"io/ioutil"
-	"os"
 	"path/filepath"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"golang.org/x/tools/go/packages"
-	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/go-typemapper/generator"
+	"github.com/paultyng/go-typemapper/typemappertest"
 )
 
 var update = flag.Bool("update", false, "update generated files")
 
-func loadSSAPackage(t *testing.T, path string) *ssa.Package {
-	t.Helper()
-
-	cfg := &packages.Config{
-		Dir:        path,
-		Mode:       packages.LoadSyntax,
-		BuildFlags: []string{"-tags", BuildTag, "-a"},
-		Tests:      false,
-	}
-	pkgs, err := packages.Load(cfg, "./")
+func TestTestData(t *testing.T) {
+	testDataPath, err := filepath.Abs("./testdata")
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(pkgs) != 1 {
-		t.Fatalf("error: %d packages found", len(pkgs))
+	g := typemappertest.Generate(t, testDataPath, typemappertest.Update(*update))
+
+	messages := []string{}
+	for _, d := range g.Diagnostics() {
+		if d.Mapping == "MapResponseView" {
+			messages = append(messages, d.Message)
+		}
 	}
-	pkg := pkgs[0]
-
-	prog := ssa.NewProgram(pkg.Fset, ssa.BuilderMode(0))
-
-	// Create SSA packages for all imports.
-	// Order is not significant.
-	created := make(map[*types.Package]bool)
-	var createAll func(pkgs []*types.Package)
-	createAll = func(pkgs []*types.Package) {
-		for _, p := range pkgs {
-			if !created[p] {
-				created[p] = true
-				prog.CreatePackage(p, nil, nil, true)
-				createAll(p.Imports())
-			}
+	require.Equal(t, []string{
+		"dst.Items shares a slice with src.Items",
+		"dst.Meta shares a map with src.Meta",
+		"dst.Owner shares a pointer with src.Owner",
+		"dst.Root shares references in TreeNode with src.Root",
+		"dst.Status points to src.Status",
+	}, messages)
+
+	messages = []string{}
+	for _, d := range g.Diagnostics() {
+		if d.Mapping == "MapCustomers" {
+			messages = append(messages, d.Message)
 		}
 	}
-	createAll(pkg.Types.Imports())
+	require.Equal(t, []string{
+		"elements of dst share a pointer with elements of src",
+	}, messages)
+}
+
+func TestStrictCopy(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	// Create and build the primary package.
-	ssapkg := prog.CreatePackage(pkg.Types, pkg.Syntax, pkg.TypesInfo, false)
-	ssapkg.Build()
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath)).StrictCopy()
+	err = g.GenerateMappings()
+	require.NoError(t, err)
+	require.Empty(t, g.Diagnostics())
 
-	return ssapkg
+	buf := &bytes.Buffer{}
+	err = g.Render("mapaliasing.generated.go", buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "dst.Status = deepCopyPtrString(&src.Status, seen)")
+	require.Contains(t, buf.String(), "dst = append(dst, deepCopyPtrCustomer(x, seen))")
 }
 
-func testGeneratedPackage(t *testing.T, pkgPath string) {
-	t.Helper()
+func TestFuzz(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath)).Fuzz()
+	err = g.GenerateMappings()
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = g.Render("mapstructs.generated_test.go", buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `func FuzzMapStructSrcDstParamsError(f *testing.F) {
+	f.Add("", 0, false, "", false, "", "")
+	f.Fuzz(func(t *testing.T, srcStringMatch string, srcIntMatch int, srcBoolMatch bool, srcPointerMatch string, srcDerefMatchSet bool, srcDerefMatch string, srcTypeAliasMatch string) {
+		src := fuzzSourceStruct(srcStringMatch, srcIntMatch, srcBoolMatch, srcPointerMatch, srcDerefMatchSet, srcDerefMatch, srcTypeAliasMatch)
+		dst := new(DestStruct)
+		_ = MapStructSrcDstParamsError(src, dst)
+	})
+}`)
+	require.Contains(t, buf.String(), `	if derefMatchSet {
+		x := derefMatch
+		v.DerefMatch = &x
+	}`)
+	// colliding names get a numeric suffix
+	require.Contains(t, buf.String(), "func fuzzFuzzNames(countSet bool, count int, countSet2 bool, x2 int, aBC string, aBC2 string) FuzzNames {")
+	require.NotContains(t, buf.String(), "func FuzzMapStructSrcDestParams(")
+}
+
+func TestBench(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath)).Bench().MinimizeAllocs()
+	err = g.GenerateMappings()
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = g.Render("mapslices.generated_test.go", buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `func BenchmarkMapSliceSrcParamsDestConst(b *testing.B) {
+	src := make([]string, 1000)
+	for i := range src {
+		src[i] = "src 1"
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = MapSliceSrcParamsDestConst(src)
+	}
+}`)
 
-	assert := require.New(t)
+	buf.Reset()
+	err = g.Render("mapstructs.generated.go", buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "dst := &DestStruct{")
+}
 
-	ssapkg := loadSSAPackage(t, pkgPath)
+func TestAmbiguousAutoMapWith(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata/ambiguous")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath))
+	err = g.GenerateMappings()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ambiguous mapping functions for Child to ChildView in MapParent: MapChild, MapChildAgain")
+}
 
-	g := NewGenerator(
-		ssapkg,
-		"// Code generated by \"typemapper \"; DO NOT EDIT.\n",
-		fmt.Sprintf("// +build !%s", BuildTag),
-	)
-	err := g.GenerateMappings()
-	assert.NoError(err)
+func TestAmbiguousConverters(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata/ambiguousconverters")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	for _, fileName := range g.AllFiles() {
-		t.Run(fileName, func(t *testing.T) {
-			assert := require.New(t)
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath))
+	err = g.GenerateMappings()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ambiguous converters for time.Duration to string in MapRequestView: DurationToString, FormatDuration, use MapWith to choose one")
+}
 
-			outFile := filepath.Join(pkgPath, fileName)
-			expectedBytes, err := ioutil.ReadFile(outFile)
-			if !os.IsNotExist(err) {
-				assert.NoError(err)
-			}
-			expected := strings.ReplaceAll(string(expectedBytes), "\r\n", "\n")
-
-			actualBuf := &bytes.Buffer{}
-			err = g.Render(fileName, actualBuf)
-			assert.NoError(err)
-
-			if *update {
-				err = ioutil.WriteFile(outFile, actualBuf.Bytes(), 0777)
-				assert.NoError(err)
-			} else {
-				actual := strings.ReplaceAll(actualBuf.String(), "\r\n", "\n")
-				assert.Equal(expected, actual)
-			}
-		})
+func TestAmbiguousContext(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata/ambiguouscontext")
+	if err != nil {
+		t.Fatal(err)
 	}
+
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath))
+	err = g.GenerateMappings()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to pass names to ResolveProduct: ambiguous parameters of MapOrderLine of type example.com/testdata/ambiguouscontext.NameLookup: names, fallback")
 }
 
-func TestTestData(t *testing.T) {
-	testDataPath, err := filepath.Abs("./testdata")
+func TestConflictingSourceFields(t *testing.T) {
+	pkgPath, err := filepath.Abs("./testdata/conflict")
 	if err != nil {
 		t.Fatal(err)
 	}
-	testGeneratedPackage(t, testDataPath)
+
+	g := generator.NewGenerator(typemappertest.Load(t, pkgPath))
+	err = g.GenerateMappings()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ambiguous source fields in MapNode: dst.Name matches src.ServiceName, src.GetName, choose one with typemapper.MapField(src.ServiceName, dst.Name)")
 }
 
 func TestExamples(t *testing.T) {
@@ -128,7 +193,7 @@ func TestExamples(t *testing.T) {
 			}
 			pkgPath := filepath.Join(examplesPath, c.Name())
 
-			testGeneratedPackage(t, pkgPath)
+			typemappertest.Generate(t, pkgPath, typemappertest.Update(*update))
 		})
 	}
 }
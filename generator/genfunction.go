@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"go/types"
+	"strconv"
+	"strings"
 
 	. "github.com/dave/jennifer/jen"
 	"github.com/pkg/errors"
@@ -16,6 +18,10 @@ const (
 )
 
 func (g *Generator) generateSliceMapping(mf *mappingFunc) error {
+	if len(mf.addedSrcs) > 0 {
+		return errors.Errorf("AddSource is only supported for struct mappings, found in %s", mf.name)
+	}
+
 	srcName := mf.srcName
 	if srcName == "" {
 		srcName = defaultSrcName
@@ -49,35 +55,128 @@ func (g *Generator) generateSliceMapping(mf *mappingFunc) error {
 		body = append(body,
 			Var().Id(dstName).Add(g.genType(mf.dstType)),
 		)
+		if g.minimize(mf) {
+			body = append(body, g.preallocate(Id(dstName), Id(srcName), mf.dstType))
+		}
 	}
 
 	// TODO: nil checks
 	srcElemType := unwrapSlice(mf.srcType).Elem()
 	dstElemType := unwrapSlice(mf.dstType).Elem()
 	iter := "x"
-	srcExpr := g.convertSourceTo(mf.MapWith(g.cache), Id(iter), srcElemType, dstElemType)
+	srcExpr, err := g.deepCopyValue(mf, Id(iter), srcElemType, dstElemType)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if srcExpr == nil {
+		srcExpr, err = g.convertSourceTo(mf, Id(iter), srcElemType, dstElemType)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if g.deepCopySeenUsed(mf, srcElemType, dstElemType) {
+		body = append(body, g.deepCopySeen(mf, srcName, dstName))
+	}
+	g.diagnoseElementAliasing(mf, srcName, dstName)
 
+	body = append(body, g.chainComment(mf, dstName, srcElemType, dstElemType)...)
 	body = append(body,
 		For(List(Id("_"), Id(iter)).Op(":=").Range().Id(srcName)).Block(
 			Id(dstName).Op("=").Append(Id(dstName), srcExpr),
 		),
 	)
 
+	body = append(body, returnSuccess.Clone())
+	g.funcDecl(mf).Block(body...)
+
+	testBody := g.generateSliceMappingTest(mf, srcName, dstName)
+	g.testFile(mf.fileName).Func().Id(fmt.Sprintf("Test%s", mf.name)).Params(Id("t").Op("*").Qual("testing", "T")).Block(testBody...)
+	if g.bench {
+		if bench := g.generateSliceMappingBenchmark(mf, srcName, dstName); bench != nil {
+			g.benchmark(mf, bench)
+		}
+	}
+
+	return nil
+}
+
+// generateComposedMapping generates a mapping that calls a chain of other
+// mapping functions through intermediate types.
+func (g *Generator) generateComposedMapping(mf *mappingFunc, chain mappingCache) error {
+	srcName := mf.srcName
+	if srcName == "" {
+		srcName = defaultSrcName
+	}
+
+	dstName := mf.dstName
+	if dstName == "" {
+		dstName = defaultDstName
+	}
+
+	returnsSuccess := []Code{}
+
+	if mf.dstReturned {
+		returnsSuccess = append(returnsSuccess, Id(dstName))
+	}
+	if mf.errReturned {
+		returnsSuccess = append(returnsSuccess, Nil())
+	}
+
+	returnSuccess := Return(returnsSuccess...)
+
+	srcExpr, err := g.callMapWith(mf, &mappingFunc{chain: chain}, Id(srcName))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	last := chain[len(chain)-1]
+
+	comment := Commentf("mapped through %s", chain.names(" -> "))
+
+	body := []Code{}
+	switch {
+	case mf.dstConstructed && last.returns(mf.dstType):
+		body = append(body,
+			comment,
+			Id(dstName).Op(":=").Add(srcExpr),
+		)
+	case !mf.dstConstructed && isPointer(mf.dstType) && last.returns(unwrapPointer(mf.dstType)):
+		body = append(body,
+			If(Id(dstName).Op("==").Nil()).Block(
+				returnSuccess.Clone(),
+			),
+			comment,
+			Op("*").Id(dstName).Op("=").Add(srcExpr),
+		)
+	default:
+		return errors.Errorf("unable to compose %s, %s does not return %s", mf.name, last.name, g.typeString(mf.dstType))
+	}
+
+	body = append(body, returnSuccess.Clone())
+	g.funcDecl(mf).Block(body...)
+
+	// no test is generated, the mappings of the chain are tested on their own
+	return nil
+}
+
+// funcDecl generates the declaration of the mapping function, keeping the
+// receiver, type parameters and parameters of the typemapper declaration.
+func (g *Generator) funcDecl(mf *mappingFunc) *Statement {
 	params := []Code{}
-	srcParam := Id(srcName).Add(g.genType(mf.srcType))
+	for _, p := range mf.fn.Params {
+		params = append(params, Id(p.Name()).Add(g.genType(p.Type())))
+	}
 
 	s := g.file(mf.fileName).Func()
 	if mf.srcReceiver {
-		s = s.Params(srcParam.Clone())
-	} else {
-		params = append(params, srcParam.Clone())
+		s = s.Params(params[0])
+		params = params[1:]
 	}
 
-	if !mf.dstConstructed {
-		params = append(params, Id(dstName).Add(g.genType(mf.dstType)))
+	s = s.Id(mf.name)
+	if tparams := mf.fn.Signature.TypeParams(); tparams.Len() > 0 {
+		s = s.Types(g.genTypeParams(tparams)...)
 	}
-
-	s = s.Id(mf.name).Params(params...)
+	s = s.Params(params...)
 
 	switch {
 	case mf.dstReturned && mf.errReturned:
@@ -88,23 +187,15 @@ func (g *Generator) generateSliceMapping(mf *mappingFunc) error {
 		s = s.Add(g.genType(mf.dstType))
 	}
 
-	body = append(body, returnSuccess.Clone())
-	s.Block(body...)
-
-	g.testFile(mf.fileName).Func().Id(fmt.Sprintf("Test%s", mf.name)).Params(Id("t").Op("*").Qual("testing", "T")).Block()
-
-	return nil
+	return s
 }
 
 func (g *Generator) generateStructMapping(mf *mappingFunc) error {
-	m := mf.Mapper()
-
-	if m == nil {
-		return errors.Errorf("unable to create struct mapping for %v and %v", mf.srcType, mf.dstType)
+	mapConfig, err := g.mapConfiguration(mf)
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
-	mapConfig := m.Map()
-
 	fileName := mf.fileName
 
 	srcName := mf.srcName
@@ -117,6 +208,11 @@ func (g *Generator) generateStructMapping(mf *mappingFunc) error {
 		dstName = defaultDstName
 	}
 
+	srcNames := mf.sourceNames()
+	if len(mapConfig.Conflicts) > 0 {
+		return conflictError(mf, srcNames, dstName, mapConfig.Conflicts)
+	}
+
 	returnsSuccess := []Code{}
 
 	if mf.dstReturned {
@@ -128,9 +224,31 @@ func (g *Generator) generateStructMapping(mf *mappingFunc) error {
 
 	returnSuccess := Return(returnsSuccess...)
 
+	// with MinimizeAllocs, fields assigned directly are set in the struct
+	// literal constructing the destination
+	literal := Dict{}
+	assignments := []Code{}
+	allocated := map[string]bool{}
+	for _, p := range mapConfig.Pairs {
+		code, value, err := g.generateFieldAssignment(mf, srcNames[p.SourceIndex], dstName, p)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		g.diagnoseAliasing(mf, srcNames[p.SourceIndex], dstName, p)
+		if g.minimize(mf) && mf.dstConstructed && value != nil && len(code) == 1 && !nested(p.Source) && !nested(p.Destination) {
+			literal[Id(p.Destination.Name())] = value
+			continue
+		}
+		assignments = append(assignments, g.guardParents(srcNames[p.SourceIndex], dstName, p, code, allocated)...)
+	}
+
 	body := []Code{}
 
 	switch {
+	case mf.dstConstructed && isPointer(mf.dstType) && g.minimize(mf):
+		body = append(body,
+			Id(dstName).Op(":=").Op("&").Add(g.genType(unwrapPointer(mf.dstType))).Values(literal),
+		)
 	case mf.dstConstructed && isPointer(mf.dstType):
 		// construct with `new`
 		body = append(body,
@@ -139,7 +257,7 @@ func (g *Generator) generateStructMapping(mf *mappingFunc) error {
 	case mf.dstConstructed:
 		// constructed but not a pointer type (no `new`)
 		body = append(body,
-			Id(dstName).Op(":=").Add(g.genType(mf.dstType)).Values(),
+			Id(dstName).Op(":=").Add(g.genType(mf.dstType)).Values(literal),
 		)
 	case isPointer(mf.dstType):
 		// pointer but not constructed
@@ -149,84 +267,224 @@ func (g *Generator) generateStructMapping(mf *mappingFunc) error {
 	}
 
 	for _, p := range mapConfig.Pairs {
-		body = append(body, g.generateFieldAssignment(mf.MapWith(g.cache), srcName, dstName, p)...)
+		if g.deepCopySeenUsed(mf, p.Source.Type(), p.Destination.Type()) {
+			body = append(body, g.deepCopySeen(mf, srcNames[0], dstName))
+			break
+		}
 	}
+	body = append(body, assignments...)
+	suggestions := []string{}
 	for _, n := range mapConfig.NoMatch {
-		body = append(body, Commentf("no match for %q", n.Name()))
+		comment := fmt.Sprintf("no match for %q", n.Path())
+		if len(srcNames) > 1 {
+			comment = fmt.Sprintf("%s in %s", comment, strings.Join(srcNames, ", "))
+		}
+		calls := suggestionCalls(srcNames, dstName, n, mapConfig.Suggestions)
+		if len(calls) == 0 {
+			body = append(body, Comment(comment))
+			continue
+		}
+		body = append(body, Comment(comment+", did you mean:"))
+		for _, c := range calls {
+			body = append(body, Comment(c))
+		}
+		suggestions = append(suggestions, calls...)
 	}
-
-	params := []Code{}
-	srcParam := Id(srcName).Add(g.genType(mf.srcType))
-
-	s := g.file(fileName).Func()
-	if mf.srcReceiver {
-		s = s.Params(srcParam.Clone())
-	} else {
-		params = append(params, srcParam.Clone())
+	for _, n := range mapConfig.Ambiguous {
+		body = append(body, Commentf("ambiguous selector %q", n.Path()))
 	}
-
-	if !mf.dstConstructed {
-		params = append(params, Id(dstName).Add(g.genType(mf.dstType)))
+	mismatches := []string{}
+	for _, tm := range mapConfig.TypeMismatch {
+		mismatch := g.mismatchMessage(srcNames[tm.SourceIndex], dstName, tm)
+		mismatches = append(mismatches, mismatch)
+		body = append(body, Comment("type mismatch for "+mismatch))
 	}
-
-	s = s.Id(mf.name).Params(params...)
-
-	switch {
-	case mf.dstReturned && mf.errReturned:
-		s = s.Params(g.genType(mf.dstType), Error())
-	case mf.errReturned:
-		s = s.Error()
-	case mf.dstReturned:
-		s = s.Add(g.genType(mf.dstType))
+	if len(mapConfig.Ignored) > 0 {
+		body = append(body, Commentf("ignored %s", quotedPaths(mapConfig.Ignored)))
+	}
+	if len(mapConfig.Inaccessible) > 0 {
+		body = append(body, Commentf("inaccessible %s", quotedPaths(mapConfig.Inaccessible)))
+	}
+	strict := g.strict || mf.strict
+	unusedNames := []string{}
+	for _, u := range mapConfig.Unused {
+		unusedNames = append(unusedNames, srcNames[u.SourceIndex]+"."+u.Source.Path())
+	}
+	if strict && len(unusedNames) > 0 {
+		body = append(body, Commentf("unused source fields %s", strings.Join(unusedNames, ", ")))
 	}
 
 	body = append(body, returnSuccess.Clone())
-	s.Block(body...)
+	g.funcDecl(mf).Block(body...)
 
 	//generate test func
 	testBody := []Code{}
 	noMatchNames := []string{}
 	for _, n := range mapConfig.NoMatch {
-		noMatchNames = append(noMatchNames, n.Name())
+		noMatchNames = append(noMatchNames, n.Path())
+	}
+	ambiguousNames := []string{}
+	for _, n := range mapConfig.Ambiguous {
+		ambiguousNames = append(ambiguousNames, n.Path())
 	}
+	msgs := []string{}
 	if len(noMatchNames) > 0 {
+		msg := fmt.Sprintf("no mapping for: %v", noMatchNames)
+		if len(srcNames) > 1 {
+			msg = fmt.Sprintf("%s in %s", msg, strings.Join(srcNames, ", "))
+		}
+		if len(suggestions) > 0 {
+			msg = fmt.Sprintf("%s, did you mean: %s", msg, strings.Join(suggestions, ", "))
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(ambiguousNames) > 0 {
+		msgs = append(msgs, fmt.Sprintf("ambiguous selectors: %v", ambiguousNames))
+	}
+	if len(mismatches) > 0 {
+		msgs = append(msgs, "type mismatch for "+strings.Join(mismatches, ", "))
+	}
+	if strict && len(unusedNames) > 0 {
+		msgs = append(msgs, fmt.Sprintf("unused source fields: %v", unusedNames))
+	}
+	if len(msgs) > 0 {
 		testBody = append(testBody,
-			Id("t").Dot("Fatal").Params(Lit(fmt.Sprintf("no mapping for: %v", noMatchNames))),
+			Id("t").Dot("Fatal").Params(Lit(strings.Join(msgs, "; "))),
 		)
+	} else {
+		testBody = g.generateStructMappingTest(mf, mapConfig)
 	}
 
 	g.testFile(fileName).Func().Id(fmt.Sprintf("Test%s", mf.name)).Params(Id("t").Op("*").Qual("testing", "T")).Block(testBody...)
+	if upToDate := g.generateUpToDateTest(mf, srcNames, dstName, strict); upToDate != nil {
+		g.testFile(fileName).Func().Id(fmt.Sprintf("Test%sUpToDate", mf.name)).Params(Id("t").Op("*").Qual("testing", "T")).Block(upToDate...)
+	}
+	if g.bench {
+		if bench := g.generateStructMappingBenchmark(mf, mapConfig); bench != nil {
+			g.benchmark(mf, bench)
+		}
+	}
 	return nil
 }
 
-func (g *Generator) callMapWith(mapWith *mappingFunc, srcExpr *Statement) *Statement {
-	if mapWith.errReturned {
-		panic("err return values not yet supported for MapWith")
-	}
-	if !mapWith.dstConstructed {
-		panic("destination parameters not yet supported for MapWith")
-	}
-	if mapWith.srcReceiver {
-		return srcExpr.Dot(mapWith.name).Params()
+// benchmark adds the benchmark of the mapping to its test file.
+func (g *Generator) benchmark(mf *mappingFunc, body []Code) {
+	g.testFile(mf.fileName).Func().Id(fmt.Sprintf("Benchmark%s", mf.name)).Params(Id("b").Op("*").Qual("testing", "B")).Block(body...)
+}
+
+// mapConfiguration matches the fields of the struct mapping mf.
+func (g *Generator) mapConfiguration(mf *mappingFunc) (mapper.MapConfiguration, error) {
+	m := mf.Mapper(func(src, dst types.Type) bool {
+		return g.convertible(mf, src, dst) || g.wrapperConvertible(mf, src, dst)
+	})
+
+	if m == nil {
+		return mapper.MapConfiguration{}, errors.Errorf("unable to create struct mapping for %v and %v", mf.srcType, mf.dstType)
 	}
+	return m.FromPackage(g.ssapkg.Pkg).Map(), nil
+}
 
-	return Id(mapWith.name).Params(srcExpr)
+// conflictError describes destination fields matching several source
+// fields, with a MapField call choosing the first candidate.
+func conflictError(mf *mappingFunc, srcNames []string, dstName string, conflicts []mapper.Conflict) error {
+	msgs := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		srcName := srcNames[c.SourceIndex]
+		candidates := make([]string, 0, len(c.Candidates))
+		for _, f := range c.Candidates {
+			candidates = append(candidates, srcName+"."+f.Path())
+		}
+		msgs = append(msgs, fmt.Sprintf("%s.%s matches %s, choose one with typemapper.MapField(%s, %s.%s)",
+			dstName, c.Destination.Path(), strings.Join(candidates, ", "), candidates[0], dstName, c.Destination.Path()))
+	}
+	return errors.Errorf("ambiguous source fields in %s: %s", mf.name, strings.Join(msgs, "; "))
 }
 
-func (g *Generator) convertSourceTo(mapWith mappingCache, srcExpr *Statement, srcType, dstType types.Type) *Statement {
-	for _, mw := range mapWith {
-		if !types.AssignableTo(dstType, mw.dstType) {
+// suggestionCalls returns MapField calls mapping the suggested source fields
+// to the destination field.
+func suggestionCalls(srcNames []string, dstName string, dstField mapper.Field, suggestions []mapper.Suggestion) []string {
+	calls := []string{}
+	for _, s := range suggestions {
+		if s.Destination.Path() != dstField.Path() {
 			continue
 		}
+		calls = append(calls, fmt.Sprintf("typemapper.MapField(%s.%s, %s.%s)", srcNames[s.SourceIndex], s.Source.Path(), dstName, dstField.Path()))
+	}
+	return calls
+}
+
+// mismatchMessage describes the types of a field matching by name but not
+// by type, with the signature of a converter to add.
+func (g *Generator) mismatchMessage(srcName, dstName string, tm mapper.TypeMismatch) string {
+	srcType, dstType := g.typeString(tm.Source.Type()), g.typeString(tm.Destination.Type())
+	return fmt.Sprintf("%q: %s.%s is %s and %s.%s is %s, convert with typemapper.MapWith(func(%s) %s)",
+		tm.Destination.Path(), srcName, tm.Source.Path(), srcType, dstName, tm.Destination.Path(), dstType, srcType, dstType)
+}
 
-		if types.AssignableTo(srcType, mw.srcType) {
-			return g.callMapWith(mw, srcExpr)
+// quotedPaths returns the quoted paths of the fields separated by commas.
+func quotedPaths(fields []mapper.Field) string {
+	paths := make([]string, 0, len(fields))
+	for _, f := range fields {
+		paths = append(paths, strconv.Quote(f.Path()))
+	}
+	return strings.Join(paths, ", ")
+}
+
+// callMapWith calls the converter mapWith on srcExpr, passing parameters of
+// the mapping mf for any context arguments of the converter.
+func (g *Generator) callMapWith(mf *mappingFunc, mapWith *mappingFunc, srcExpr *Statement) (*Statement, error) {
+	if len(mapWith.chain) > 0 {
+		for _, mw := range mapWith.chain {
+			var err error
+			srcExpr, err = g.callMapWith(mf, mw, srcExpr)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
 		}
+		return srcExpr, nil
+	}
 
-		// TODO: handle multiple srcTypes when pointer receiver type?
-		if mw.srcReceiver && isPointer(mw.srcType) && types.AssignableTo(srcType, unwrapPointer(mw.srcType)) {
-			return g.callMapWith(mw, srcExpr)
+	if mapWith.errReturned {
+		return nil, errors.Errorf("MapWith function %s in %s returns an error, which is not yet supported", mapWith.name, mf.name)
+	}
+	if !mapWith.dstConstructed {
+		return nil, errors.Errorf("MapWith function %s in %s takes a destination parameter, which is not yet supported", mapWith.name, mf.name)
+	}
+
+	args := []Code{}
+	for i, p := range mapWith.params {
+		if i == mapWith.srcParam {
+			args = append(args, srcExpr)
+			continue
 		}
+		ctx, err := mf.contextParam(p.Type())
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to pass %s to %s", p.Name(), mapWith.name)
+		}
+		if ctx == nil {
+			return nil, errors.Errorf("no parameter of %s to pass as %s to %s", mf.name, p.Name(), mapWith.name)
+		}
+		args = append(args, Id(ctx.Name()))
+	}
+
+	if mapWith.srcReceiver {
+		return srcExpr.Dot(mapWith.name).Params(args...), nil
+	}
+
+	if fn := mapWith.fn; fn != nil && fn.Pkg != nil && fn.Pkg.Pkg != g.ssapkg.Pkg {
+		return Qual(fn.Pkg.Pkg.Path(), mapWith.name).Params(args...), nil
+	}
+
+	return Id(mapWith.name).Params(args...), nil
+}
+
+func (g *Generator) convertSourceTo(mf *mappingFunc, srcExpr *Statement, srcType, dstType types.Type) (*Statement, error) {
+	mw, err := g.converter(mf, srcType, dstType)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if mw != nil {
+		return g.callMapWith(mf, mw, srcExpr)
 	}
 
 	srcExpr = srcExpr.Clone()
@@ -264,15 +522,161 @@ func (g *Generator) convertSourceTo(mapWith mappingCache, srcExpr *Statement, sr
 	// 	// TODO: return error here?
 	// }
 
-	return srcExpr
+	return srcExpr, nil
+}
+
+// generateFieldAssignment returns the statements assigning the field of the
+// pair, and the value assigned if it is a single assignment.
+func (g *Generator) generateFieldAssignment(mf *mappingFunc, srcName, dstName string, p mapper.FieldPair) ([]Code, *Statement, error) {
+	srcExpr := fieldSelector(srcName, p.Source)
+	if p.Value {
+		srcExpr = mf.valueMaps[p.Destination.Path()].code.Clone()
+	}
+	dstExpr := fieldSelector(dstName, p.Destination)
+
+	if p.Value && isPointer(p.Destination.Type()) && !isPointer(p.Source.Type()) {
+		// computed values are not addressable, so assign a copy
+		valueExpr, err := g.convertSourceTo(mf, srcExpr, p.Source.Type(), unwrapPointer(p.Destination.Type()))
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		v := mf.localName("v")
+		return []Code{
+			Block(
+				Id(v).Op(":=").Add(valueExpr),
+				dstExpr.Op("=").Op("&").Id(v),
+			),
+		}, nil, nil
+	}
+
+	code, err := g.deepCopyAssignment(mf, srcExpr, dstExpr, p.Source.Type(), p.Destination.Type())
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if code != nil {
+		return code, nil, nil
+	}
+
+	srcSlice, dstSlice := sliceType(p.Source.Type()), sliceType(p.Destination.Type())
+	if srcSlice != nil && dstSlice != nil && !types.AssignableTo(p.Source.Type(), p.Destination.Type()) {
+		// map element by element, similar to a slice mapping
+		code := g.chainComment(mf, p.Destination.Name(), srcSlice.Elem(), dstSlice.Elem())
+		if !mf.dstConstructed {
+			code = append(code, dstExpr.Clone().Op("=").Add(dstExpr.Clone()).Index(Op(":").Lit(0)))
+		} else if g.minimize(mf) {
+			code = append(code, g.preallocate(dstExpr, srcExpr, p.Destination.Type()))
+		}
+		iter := "x"
+		elemExpr, err := g.convertSourceTo(mf, Id(iter), srcSlice.Elem(), dstSlice.Elem())
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		return append(code,
+			For(List(Id("_"), Id(iter)).Op(":=").Range().Add(srcExpr)).Block(
+				dstExpr.Clone().Op("=").Append(dstExpr.Clone(), elemExpr),
+			),
+		), nil, nil
+	}
+
+	code, err = g.wrapperAssignment(mf, srcExpr, dstExpr, p.Source.Type(), p.Destination.Type())
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if code != nil {
+		return code, nil, nil
+	}
+
+	srcExpr, err = g.convertSourceTo(mf, srcExpr, p.Source.Type(), p.Destination.Type())
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return append(
+		g.chainComment(mf, p.Destination.Name(), p.Source.Type(), p.Destination.Type()),
+		dstExpr.Op("=").Add(srcExpr.Clone()),
+	), srcExpr, nil
+}
+
+// minimize reports if the mapping is generated with fewer allocations.
+func (g *Generator) minimize(mf *mappingFunc) bool {
+	return g.minimizeAllocs || mf.minimizeAllocs
+}
+
+// preallocate allocates the destination slice of type ty with the capacity
+// of the source slice, unless the source is nil.
+func (g *Generator) preallocate(dstExpr, srcExpr *Statement, ty types.Type) Code {
+	return If(srcExpr.Clone().Op("!=").Nil()).Block(
+		dstExpr.Clone().Op("=").Make(g.genType(ty), Lit(0), Len(srcExpr.Clone())),
+	)
 }
 
-func (g *Generator) generateFieldAssignment(mapWith mappingCache, srcName, dstName string, p mapper.FieldPair) []Code {
-	srcExpr := Id(srcName).Dot(p.Source.Name())
-	srcExpr = g.convertSourceTo(mapWith, srcExpr, p.Source.Type(), p.Destination.Type())
+// nested reports if the field is selected through an embedded or nested
+// field.
+func nested(f mapper.Field) bool {
+	return len(f.Parents()) > 0
+}
 
+// fieldSelector returns the selector for the field from root, through the
+// embedded or nested fields it is selected from.
+func fieldSelector(root string, f mapper.Field) *Statement {
+	s := Id(root)
+	for _, e := range f.Parents() {
+		s = s.Dot(e.Name())
+	}
+	return s.Dot(f.Name())
+}
+
+// guardParents wraps the assignment of a promoted or nested field in nil
+// checks of the pointers selected through in the source, and allocates the
+// pointers selected through in the destination unless already allocated.
+func (g *Generator) guardParents(srcName, dstName string, p mapper.FieldPair, code []Code, allocated map[string]bool) []Code {
+	var cond *Statement
+	sel := Id(srcName)
+	for _, e := range p.Source.Parents() {
+		sel = sel.Clone().Dot(e.Name())
+		if !isPointer(e.Type()) {
+			continue
+		}
+		if cond == nil {
+			cond = sel.Clone().Op("!=").Nil()
+			continue
+		}
+		cond = cond.Op("&&").Add(sel.Clone()).Op("!=").Nil()
+	}
+
+	alloc := []Code{}
+	sel = Id(dstName)
+	path := dstName
+	for _, e := range p.Destination.Parents() {
+		sel = sel.Clone().Dot(e.Name())
+		path += "." + e.Name()
+		if !isPointer(e.Type()) || allocated[path] {
+			continue
+		}
+		alloc = append(alloc, If(sel.Clone().Op("==").Nil()).Block(
+			sel.Clone().Op("=").New(g.genType(unwrapPointer(e.Type()))),
+		))
+		if cond == nil {
+			allocated[path] = true
+		}
+	}
+	code = append(alloc, code...)
+
+	if cond == nil {
+		return code
+	}
+	return []Code{If(cond).Block(code...)}
+}
+
+// chainComment records the mapping functions used when a conversion is
+// composed through intermediate types.
+func (g *Generator) chainComment(mf *mappingFunc, name string, srcType, dstType types.Type) []Code {
+	mw, err := g.converter(mf, srcType, dstType)
+	if err != nil || mw == nil || len(mw.chain) == 0 {
+		return []Code{}
+	}
 	return []Code{
-		Id(dstName).Dot(p.Destination.Name()).Op("=").Add(srcExpr),
+		Commentf("%s mapped through %s", name, mw.name),
 	}
 }
 
@@ -283,17 +687,112 @@ func (g *Generator) genType(ty types.Type) *Statement {
 	case *types.Pointer:
 		return Op("*").Add(g.genType(ty.Elem()))
 	case *types.Named:
-		if ty.Obj().Pkg() == g.ssapkg.Pkg {
-			return Id(ty.Obj().Name())
+		var s *Statement
+		switch pkg := ty.Obj().Pkg(); {
+		case pkg == nil, pkg == g.ssapkg.Pkg:
+			s = Id(ty.Obj().Name())
+		default:
+			s = Qual(pkg.Path(), ty.Obj().Name())
+		}
+		if targs := ty.TypeArgs(); targs.Len() > 0 {
+			args := []Code{}
+			for i := 0; i < targs.Len(); i++ {
+				args = append(args, g.genType(targs.At(i)))
+			}
+			s = s.Types(args...)
 		}
-		return Qual(ty.Obj().Pkg().Path(), ty.Obj().Name())
+		return s
+	case *types.TypeParam:
+		return Id(ty.Obj().Name())
 	case *types.Basic:
 		return Id(ty.Name())
 	case *types.Slice:
 		return Index().Add(g.genType(ty.Elem()))
+	case *types.Array:
+		return Index(Lit(int(ty.Len()))).Add(g.genType(ty.Elem()))
 	case *types.Map:
 		return Map(g.genType(ty.Key())).Add(g.genType(ty.Elem()))
+	case *types.Interface:
+		items := []Code{}
+		for i := 0; i < ty.NumEmbeddeds(); i++ {
+			items = append(items, g.genType(ty.EmbeddedType(i)))
+		}
+		for i := 0; i < ty.NumExplicitMethods(); i++ {
+			m := ty.ExplicitMethod(i)
+			items = append(items, g.genSignature(Id(m.Name()), m.Type().(*types.Signature)))
+		}
+		return Interface(items...)
+	case *types.Union:
+		terms := []Code{}
+		for i := 0; i < ty.Len(); i++ {
+			term := g.genType(ty.Term(i).Type())
+			if ty.Term(i).Tilde() {
+				term = Op("~").Add(term)
+			}
+			terms = append(terms, term)
+		}
+		return Union(terms...)
+	case *types.Struct:
+		fields := []Code{}
+		for i := 0; i < ty.NumFields(); i++ {
+			f := ty.Field(i)
+			field := g.genType(f.Type())
+			if !f.Embedded() {
+				field = Id(f.Name()).Add(field)
+			}
+			if tag := ty.Tag(i); tag != "" {
+				field = field.Lit(tag)
+			}
+			fields = append(fields, field)
+		}
+		return Struct(fields...)
+	case *types.Signature:
+		return g.genSignature(Func(), ty)
 	}
 
 	panic("?")
 }
+
+// genSignature appends the parameters and results of sig to s.
+func (g *Generator) genSignature(s *Statement, sig *types.Signature) *Statement {
+	params := []Code{}
+	for i := 0; i < sig.Params().Len(); i++ {
+		params = append(params, g.genType(sig.Params().At(i).Type()))
+	}
+	s = s.Params(params...)
+	switch results := sig.Results(); results.Len() {
+	case 0:
+		return s
+	case 1:
+		return s.Add(g.genType(results.At(0).Type()))
+	default:
+		types := []Code{}
+		for i := 0; i < results.Len(); i++ {
+			types = append(types, g.genType(results.At(i).Type()))
+		}
+		return s.Params(types...)
+	}
+}
+
+func (g *Generator) genTypeParams(tparams *types.TypeParamList) []Code {
+	code := []Code{}
+	for i := 0; i < tparams.Len(); i++ {
+		tp := tparams.At(i)
+		constraint := tp.Constraint()
+
+		s := Id(tp.Obj().Name())
+		switch iface, _ := constraint.Underlying().(*types.Interface); {
+		case constraint == types.Universe.Lookup("comparable").Type():
+			s = s.Comparable()
+		case iface != nil && iface.Empty():
+			s = s.Any()
+		case iface != nil && iface.IsImplicit():
+			// [T ~int | ~string] is an implicit interface{ ~int | ~string }
+			s = s.Add(g.genType(iface.EmbeddedType(0)))
+		default:
+			s = s.Add(g.genType(constraint))
+		}
+		code = append(code, s)
+	}
+	return code
+}
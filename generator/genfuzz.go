@@ -0,0 +1,268 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+// fuzzArg is an argument of a fuzz target, named after the path of the
+// source field it is assigned to.
+type fuzzArg struct {
+	name string
+	ty   types.Type
+}
+
+// fuzzNames are the names used in a fuzz target or builder.
+type fuzzNames map[string]bool
+
+// unique returns name, with a numeric suffix if it is already used, and
+// marks it used.
+func (used fuzzNames) unique(name string) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// generateFuzzTargets generates a fuzz target for each mapping returning an
+// error, checking the mapping does not panic on the sources built from the
+// fuzz inputs.
+func (g *Generator) generateFuzzTargets() {
+	for _, mf := range g.cache {
+		if !mf.errReturned || !mf.StructMapping() || len(mf.chain) > 0 {
+			continue
+		}
+		g.generateFuzzTarget(mf)
+	}
+}
+
+func (g *Generator) generateFuzzTarget(mf *mappingFunc) {
+	if mf.fn.Signature.TypeParams().Len() > 0 || mf.fn.Signature.RecvTypeParams().Len() > 0 {
+		return
+	}
+	dstName := mf.dstName
+	if dstName == "" {
+		dstName = defaultDstName
+	}
+
+	declared := mf.declaredTypes()
+	srcNames := mf.sourceNames()
+	params := []Code{Id("t").Op("*").Qual("testing", "T")}
+	seeds := []Code{}
+	build := []Code{}
+	used := fuzzNames{"t": true, "f": true, dstName: true}
+	for _, srcName := range srcNames {
+		if used[srcName] {
+			return
+		}
+		used[srcName] = true
+	}
+	for _, srcName := range srcNames {
+		ty, ok := declared[srcName]
+		if !ok {
+			return
+		}
+		builder, args := g.fuzzBuilder(mf, ty)
+		if builder == "" {
+			return
+		}
+		values := []Code{}
+		for _, arg := range args {
+			name := used.unique(srcName + exportedName(arg.name))
+			params = append(params, Id(name).Add(g.genType(arg.ty)))
+			seeds = append(seeds, fuzzZero(g.genType(arg.ty), arg.ty))
+			values = append(values, Id(name))
+		}
+		build = append(build, Id(srcName).Op(":=").Id(builder).Call(values...))
+	}
+	if len(seeds) == 0 {
+		return
+	}
+
+	added := map[string]bool{}
+	for _, name := range srcNames[1:] {
+		added[name] = true
+	}
+	call := g.testCallSource(mf, Id(srcNames[0]), added, dstName, false, "")
+	if call == nil {
+		return
+	}
+
+	g.testFile(mf.fileName).Func().Id(fmt.Sprintf("Fuzz%s", mf.name)).Params(Id("f").Op("*").Qual("testing", "F")).Block(
+		Id("f").Dot("Add").Call(seeds...),
+		Id("f").Dot("Fuzz").Call(Func().Params(params...).Block(append(build, call...)...)),
+	)
+}
+
+// fuzzBuilder returns the name of the function building a value of ty from
+// fuzz inputs, generating it in the test file of mf if needed, and its
+// arguments. It returns an empty name if ty is not a struct or has no
+// fields to fuzz.
+func (g *Generator) fuzzBuilder(mf *mappingFunc, ty types.Type) (string, []fuzzArg) {
+	st := unwrapStruct(ty)
+	if st == nil || !g.nameable(ty) {
+		return "", nil
+	}
+	elem := unwrapPointer(ty)
+	visited := map[types.Type]bool{}
+	if named, ok := elem.(*types.Named); ok {
+		visited[named] = true
+	}
+	// v and x are the locals of the builder
+	args, stmts := g.fuzzFields(Id("v"), "", st, visited, fuzzNames{"v": true, "x": true})
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	name := "fuzz" + g.typeName(ty)
+	if g.fuzzBuilders[name] {
+		return name, args
+	}
+	g.fuzzBuilders[name] = true
+
+	params := []Code{}
+	for _, arg := range args {
+		params = append(params, Id(arg.name).Add(g.genType(arg.ty)))
+	}
+	body := []Code{Var().Id("v").Add(g.genType(ty))}
+	if isPointer(ty) {
+		body = []Code{Id("v").Op(":=").New(g.genType(elem))}
+	}
+	body = append(body, stmts...)
+	body = append(body, Return(Id("v")))
+	g.testFile(mf.fileName).Func().Id(name).Params(params...).Add(g.genType(ty)).Block(body...)
+	return name, args
+}
+
+// fuzzFields returns the arguments to fuzz the fields of st selected by sel
+// and the statements assigning them. Pointers are only allocated when an
+// extra boolean argument is set, so nil pointers are fuzzed too, and slices
+// of other types are made with a fuzzed length. Arguments are named
+// uniquely among the used names.
+func (g *Generator) fuzzFields(sel *Statement, prefix string, st *types.Struct, visited map[types.Type]bool, used fuzzNames) ([]fuzzArg, []Code) {
+	args, stmts := []fuzzArg{}, []Code{}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == "_" || !f.Exported() && f.Pkg() != g.ssapkg.Pkg || !g.nameable(f.Type()) {
+			continue
+		}
+		field := sel.Clone().Dot(f.Name())
+		name := fuzzArgName(prefix + f.Name())
+		ty := f.Type()
+		elem := unwrapPointer(ty)
+
+		if at := fuzzType(ty); at != nil {
+			name = used.unique(name)
+			args = append(args, fuzzArg{name, at})
+			stmts = append(stmts, field.Op("=").Add(g.fuzzValue(ty, at, Id(name))))
+			continue
+		}
+
+		named, _ := elem.(*types.Named)
+		if isPointer(ty) {
+			set := name + "Set"
+			if at := fuzzType(elem); at != nil {
+				set, name = used.unique(set), used.unique(name)
+				args = append(args, fuzzArg{set, types.Typ[types.Bool]}, fuzzArg{name, at})
+				stmts = append(stmts, If(Id(set)).Block(
+					Id("x").Op(":=").Add(g.fuzzValue(elem, at, Id(name))),
+					field.Op("=").Op("&").Id("x"),
+				))
+				continue
+			}
+			nested := unwrapStruct(elem)
+			if nested == nil || named == nil || visited[named] {
+				continue
+			}
+			visited[named] = true
+			set = used.unique(set)
+			nestedArgs, nestedStmts := g.fuzzFields(field, prefix+f.Name(), nested, visited, used)
+			delete(visited, named)
+			args = append(append(args, fuzzArg{set, types.Typ[types.Bool]}), nestedArgs...)
+			stmts = append(stmts, If(Id(set)).Block(
+				append([]Code{field.Clone().Op("=").New(g.genType(elem))}, nestedStmts...)...,
+			))
+			continue
+		}
+
+		switch u := ty.Underlying().(type) {
+		case *types.Struct:
+			if named == nil || visited[named] {
+				continue
+			}
+			visited[named] = true
+			nestedArgs, nestedStmts := g.fuzzFields(field, prefix+f.Name(), u, visited, used)
+			delete(visited, named)
+			args = append(args, nestedArgs...)
+			stmts = append(stmts, nestedStmts...)
+		case *types.Slice:
+			n := used.unique(name + "Len")
+			args = append(args, fuzzArg{n, types.Typ[types.Uint8]})
+			stmts = append(stmts, field.Op("=").Make(g.genType(ty), Id(n).Op("%").Lit(4)))
+		}
+	}
+	return args, stmts
+}
+
+// fuzzType returns the type of the fuzz argument for a value of ty, or nil
+// if it can not be fuzzed directly.
+func fuzzType(ty types.Type) types.Type {
+	switch u := ty.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.String, types.Bool, types.Float32, types.Float64,
+			types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+			types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			return types.Typ[u.Kind()]
+		}
+	case *types.Slice:
+		if b, ok := u.Elem().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			return types.NewSlice(types.Typ[types.Uint8])
+		}
+	}
+	return nil
+}
+
+// fuzzValue converts the fuzz argument arg of type at to ty.
+func (g *Generator) fuzzValue(ty, at types.Type, arg *Statement) *Statement {
+	if types.Identical(ty, at) {
+		return arg
+	}
+	return g.genType(ty).Call(arg)
+}
+
+// fuzzZero returns the zero seed value of the fuzz argument type at, typed
+// so it matches the fuzz target.
+func fuzzZero(code *Statement, at types.Type) Code {
+	b, ok := at.(*types.Basic)
+	if !ok {
+		return code.Call(Nil())
+	}
+	switch b.Kind() {
+	case types.Bool:
+		return False()
+	case types.String:
+		return Lit("")
+	case types.Int:
+		return Lit(0)
+	case types.Float64:
+		return Lit(0.0)
+	}
+	return code.Call(Lit(0))
+}
+
+// fuzzArgName returns an argument name for the field path, avoiding keywords
+// and predeclared names.
+func fuzzArgName(path string) string {
+	name := strings.ToLower(path[:1]) + path[1:]
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil {
+		name += "Value"
+	}
+	return name
+}
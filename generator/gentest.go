@@ -0,0 +1,640 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"math"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+
+	"github.com/paultyng/go-typemapper/mapper"
+)
+
+// benchSliceLen is the length of the sources of slice mapping benchmarks.
+const benchSliceLen = 1000
+
+// testCall returns statements declaring the sources of the mapping, and
+// statements declaring the destination and calling the mapping in the shape
+// of its signature, discarding a returned destination unless checked.
+// Returned errors fail the test or benchmark named fatal. It returns nil if
+// the mapping can not be called from a test, for example if it is generic.
+func (g *Generator) testCall(mf *mappingFunc, srcNames []string, dstName string, checked bool, fatal string) ([]Code, []Code) {
+	if mf.fn.Signature.TypeParams().Len() > 0 || mf.fn.Signature.RecvTypeParams().Len() > 0 {
+		return nil, nil
+	}
+
+	declared := mf.declaredTypes()
+	for _, name := range append(append([]string{}, srcNames...), dstName) {
+		if name == "t" || name == "b" || name == "err" || name == "_" {
+			return nil, nil
+		}
+	}
+
+	decls := []Code{}
+	for _, name := range srcNames {
+		ty, ok := declared[name]
+		if !ok || !g.nameable(ty) {
+			return nil, nil
+		}
+		decls = append(decls, g.declareTestValue(name, ty))
+	}
+
+	added := map[string]bool{}
+	for _, name := range srcNames[1:] {
+		added[name] = true
+	}
+	stmts := g.testCallSource(mf, Id(srcNames[0]), added, dstName, checked, fatal)
+	if stmts == nil {
+		return nil, nil
+	}
+	return decls, stmts
+}
+
+// testCallSource returns statements declaring the destination and calling
+// the mapping with the source expression src, passing the parameters named
+// in added as themselves. Returned errors fail the test or benchmark named
+// fatal, or are discarded if it is empty. It returns nil if the mapping can
+// not be called.
+func (g *Generator) testCallSource(mf *mappingFunc, src *Statement, added map[string]bool, dstName string, checked bool, fatal string) []Code {
+	if dstName == "t" || dstName == "b" || dstName == "err" || dstName == "_" {
+		return nil
+	}
+
+	args := []Code{}
+	var dstType types.Type
+	for i, p := range mf.params {
+		switch {
+		case i == mf.srcParam:
+			args = append(args, src.Clone())
+		case added[p.Name()]:
+			args = append(args, Id(p.Name()))
+		case !mf.dstConstructed && p.Name() == mf.dstName:
+			dstType = p.Type()
+			args = append(args, Id(dstName))
+		case isContext(p.Type()):
+			args = append(args, Qual("context", "Background").Call())
+		default:
+			if !g.nameable(p.Type()) {
+				return nil
+			}
+			args = append(args, g.zeroValue(p.Type()))
+		}
+	}
+
+	stmts := []Code{}
+	switch {
+	case mf.dstConstructed:
+	case dstType == nil || !g.nameable(dstType):
+		return nil
+	case sliceType(dstType) != nil && !isPointer(dstType):
+		// slice parameters are mapped in place
+		stmts = append(stmts, Id(dstName).Op(":=").Make(g.genType(dstType), Len(src.Clone())))
+	case isPointer(dstType):
+		stmts = append(stmts, Id(dstName).Op(":=").New(g.genType(unwrapPointer(dstType))))
+	default:
+		return nil
+	}
+
+	call := Id(mf.name).Call(args...)
+	if mf.srcReceiver {
+		call = src.Clone().Dot(mf.name).Call(args...)
+	}
+
+	results := []Code{}
+	if mf.dstReturned && checked {
+		results = append(results, Id(dstName))
+	} else if mf.dstReturned {
+		results = append(results, Id("_"))
+	}
+	if mf.errReturned && fatal != "" {
+		results = append(results, Err())
+	} else if mf.errReturned {
+		results = append(results, Id("_"))
+	}
+	switch {
+	case len(results) == 0:
+		stmts = append(stmts, call)
+	case (!mf.dstReturned || !checked) && (!mf.errReturned || fatal == ""):
+		stmts = append(stmts, List(results...).Op("=").Add(call))
+	default:
+		stmts = append(stmts, List(results...).Op(":=").Add(call))
+	}
+	if mf.errReturned && fatal != "" {
+		stmts = append(stmts, If(Err().Op("!=").Nil()).Block(
+			Id(fatal).Dot("Fatal").Call(Err()),
+		))
+	}
+	return stmts
+}
+
+// declareTestValue declares a variable for the source of a test, allocated
+// if it is a pointer.
+func (g *Generator) declareTestValue(name string, ty types.Type) Code {
+	if isPointer(ty) {
+		return Id(name).Op(":=").New(g.genType(unwrapPointer(ty)))
+	}
+	return Var().Id(name).Add(g.genType(ty))
+}
+
+// generateStructMappingTest fills the sources with a distinct sentinel value
+// for each field, calls the mapping and checks the sentinels are copied to
+// the destination fields mapped without conversion. Fields converted by
+// mapping functions are checked by the tests of those functions.
+func (g *Generator) generateStructMappingTest(mf *mappingFunc, mapConfig mapper.MapConfiguration) []Code {
+	srcNames := mf.sourceNames()
+	dstName := mf.dstName
+	if dstName == "" {
+		dstName = defaultDstName
+	}
+
+	fill, checks := g.fillSources(mf, mapConfig, srcNames, dstName)
+	decls, call := g.testCall(mf, srcNames, dstName, len(checks) > 0, "t")
+	if call == nil {
+		return nil
+	}
+	body := append(decls, fill...)
+	body = append(body, call...)
+	return append(body, checks...)
+}
+
+// generateUpToDateTest returns a test failing when fields were added to the
+// destination since the mapping was generated, or to the sources if strict.
+// It returns nil if the types can not be named.
+func (g *Generator) generateUpToDateTest(mf *mappingFunc, srcNames []string, dstName string, strict bool) []Code {
+	if mf.fn.Signature.TypeParams().Len() > 0 || mf.fn.Signature.RecvTypeParams().Len() > 0 {
+		return nil
+	}
+	names := []string{dstName}
+	tys := []types.Type{unwrapPointer(mf.dstType)}
+	if strict {
+		for i, srcName := range srcNames {
+			names = append(names, srcName)
+			tys = append(tys, unwrapPointer(mf.sourceType(i)))
+		}
+	}
+
+	body := []Code{}
+	for i, ty := range tys {
+		st := unwrapStruct(ty)
+		if st == nil || !g.nameable(ty) {
+			return nil
+		}
+		// fields that are not accessible are not mapped, so they can be added
+		// without regenerating
+		named, _ := ty.(*types.Named)
+		exported := named != nil && named.Obj().Pkg() != g.ssapkg.Pkg
+		args := []Code{Lit(names[i]), Qual("reflect", "TypeOf").Call(g.genType(ty).Values()), Lit(exported)}
+		for j := 0; j < st.NumFields(); j++ {
+			args = append(args, Lit(st.Field(j).Name()))
+		}
+		call := Id(staleFieldsName).Call(args...)
+		if i == 0 {
+			body = append(body, Id("fields").Op(":=").Add(call))
+			continue
+		}
+		body = append(body, Id("fields").Op("=").Append(Id("fields"), call.Op("...")))
+	}
+	g.generateStaleFieldsFunc(mf)
+	return append(body, If(Len(Id("fields")).Op(">").Lit(0)).Block(
+		Id("t").Dot("Errorf").Call(Lit("regenerate: new fields %s"), Qual("strings", "Join").Call(Id("fields"), Lit(", "))),
+	))
+}
+
+// staleFieldsName is the function listing the fields of a type that are not
+// known to the generated up to date tests.
+const staleFieldsName = "staleFields"
+
+// generateStaleFieldsFunc generates the function listing new fields in the
+// test file of mf, unless it was already generated.
+func (g *Generator) generateStaleFieldsFunc(mf *mappingFunc) {
+	if g.staleFieldsFunc {
+		return
+	}
+	g.staleFieldsFunc = true
+
+	f := g.testFile(mf.fileName)
+	f.Commentf("%s returns the fields of ty that are not known, prefixed with name.", staleFieldsName)
+	f.Func().Id(staleFieldsName).Params(
+		Id("name").String(),
+		Id("ty").Qual("reflect", "Type"),
+		Id("exported").Bool(),
+		Id("known").Op("...").String(),
+	).Index().String().Block(
+		Id("seen").Op(":=").Map(String()).Bool().Values(),
+		For(List(Id("_"), Id("k")).Op(":=").Range().Id("known")).Block(
+			Id("seen").Index(Id("k")).Op("=").True(),
+		),
+		Id("fields").Op(":=").Index().String().Values(),
+		For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("ty").Dot("NumField").Call(), Id("i").Op("++")).Block(
+			Id("f").Op(":=").Id("ty").Dot("Field").Call(Id("i")),
+			If(Id("exported").Op("&&").Op("!").Id("f").Dot("IsExported").Call()).Block(Continue()),
+			If(Op("!").Id("seen").Index(Id("f").Dot("Name"))).Block(
+				Id("fields").Op("=").Append(Id("fields"), Id("name").Op("+").Lit(".").Op("+").Id("f").Dot("Name")),
+			),
+		),
+		Return(Id("fields")),
+	)
+}
+
+// fillSources returns the statements filling the sources with a distinct
+// sentinel value for each field, and the checks of the destination fields
+// of the pairs mapped without conversion.
+func (g *Generator) fillSources(mf *mappingFunc, mapConfig mapper.MapConfiguration, srcNames []string, dstName string) ([]Code, []Code) {
+	fill, checks := []Code{}, []Code{}
+	allocated := map[string]bool{}
+	sentinels := map[string]*sentinelValue{}
+	fillField := func(srcName string, parents []mapper.Field, name string, ty types.Type) *sentinelValue {
+		path := srcName
+		for _, parent := range parents {
+			path += "." + parent.Name()
+		}
+		path += "." + name
+		if sentinel, ok := sentinels[path]; ok {
+			return sentinel
+		}
+		sentinel, assign := g.fillSource(srcName, parents, name, ty, len(sentinels)+1, allocated)
+		if sentinel != nil {
+			sentinels[path] = sentinel
+			fill = append(fill, assign...)
+		}
+		return sentinel
+	}
+
+	for i, srcName := range srcNames {
+		st := unwrapStruct(mf.sourceType(i))
+		for j := 0; st != nil && j < st.NumFields(); j++ {
+			f := st.Field(j)
+			if f.Name() == "_" || !f.Exported() && f.Pkg() != g.ssapkg.Pkg {
+				continue
+			}
+			fillField(srcName, nil, f.Name(), f.Type())
+		}
+	}
+
+	for _, p := range mapConfig.Pairs {
+		srcType, dstType := p.Source.Type(), p.Destination.Type()
+		if p.Value || g.converted(mf, srcType, dstType) || !g.sentinelComparable(unwrapPointer(srcType), unwrapPointer(dstType)) {
+			continue
+		}
+		sentinel := fillField(srcNames[p.SourceIndex], p.Source.Parents(), p.Source.Name(), srcType)
+		if sentinel == nil {
+			continue
+		}
+		checks = append(checks, g.checkDestination(dstName, p, sentinel))
+	}
+
+	return fill, checks
+}
+
+// generateStructMappingBenchmark fills the sources like the generated test,
+// and calls the mapping b.N times reporting its allocations.
+func (g *Generator) generateStructMappingBenchmark(mf *mappingFunc, mapConfig mapper.MapConfiguration) []Code {
+	srcNames := mf.sourceNames()
+	dstName := mf.dstName
+	if dstName == "" {
+		dstName = defaultDstName
+	}
+
+	fill, _ := g.fillSources(mf, mapConfig, srcNames, dstName)
+	decls, call := g.testCall(mf, srcNames, dstName, false, "b")
+	if call == nil {
+		return nil
+	}
+	return g.benchmarkLoop(mf, append(decls, fill...), call)
+}
+
+// generateSliceMappingTest calls the mapping with a slice of two elements
+// and checks both are mapped. Elements mapped without conversion are
+// distinct sentinels checked in the destination, other elements are zero.
+func (g *Generator) generateSliceMappingTest(mf *mappingFunc, srcName, dstName string) []Code {
+	// the source is declared with its elements instead
+	_, call := g.testCall(mf, []string{srcName}, dstName, true, "t")
+	if call == nil {
+		return nil
+	}
+	srcSlice, dstSlice := sliceType(mf.srcType), sliceType(mf.dstType)
+	if srcSlice == nil || dstSlice == nil || !g.nameable(mf.srcType) {
+		return nil
+	}
+	srcElem, dstElem := srcSlice.Elem(), dstSlice.Elem()
+
+	sentinels := []*sentinelValue{}
+	if !g.converted(mf, srcElem, dstElem) && g.sentinelComparable(srcElem, dstElem) {
+		for i := 0; i < 2; i++ {
+			if sentinel := g.sentinel(srcElem, fmt.Sprintf("%s[%d]", srcName, i), i+1); sentinel != nil {
+				sentinels = append(sentinels, sentinel)
+			}
+		}
+	}
+	elems := []Code{}
+	for i := 0; i < 2; i++ {
+		switch {
+		case len(sentinels) == 2:
+			elems = append(elems, sentinels[i].value.Clone())
+		case isPointer(srcElem):
+			elems = append(elems, New(g.genType(unwrapPointer(srcElem))))
+		default:
+			elems = append(elems, g.zeroValue(srcElem))
+		}
+	}
+
+	body := []Code{
+		Id(srcName).Op(":=").Add(g.genType(mf.srcType)).Values(elems...),
+	}
+	body = append(body, call...)
+	body = append(body, If(Len(Id(dstName)).Op("!=").Len(Id(srcName))).Block(
+		Id("t").Dot("Fatalf").Call(Lit("len("+dstName+") = %d, want %d"), Len(Id(dstName)), Len(Id(srcName))),
+	))
+	if len(sentinels) == 2 {
+		for i, sentinel := range sentinels {
+			body = append(body, g.checkValue(Id(dstName).Index(Lit(i)), fmt.Sprintf("%s[%d]", dstName, i), dstElem, sentinel))
+		}
+	}
+	return body
+}
+
+// generateSliceMappingBenchmark calls the mapping b.N times with a slice of
+// benchSliceLen elements, filled with a sentinel if there is one, reporting
+// its allocations.
+func (g *Generator) generateSliceMappingBenchmark(mf *mappingFunc, srcName, dstName string) []Code {
+	_, call := g.testCall(mf, []string{srcName}, dstName, false, "b")
+	if call == nil {
+		return nil
+	}
+	srcSlice := sliceType(mf.srcType)
+	if srcSlice == nil || !g.nameable(mf.srcType) {
+		return nil
+	}
+
+	setup := []Code{
+		Id(srcName).Op(":=").Make(g.genType(mf.srcType), Lit(benchSliceLen)),
+	}
+	var elem *Statement
+	if sentinel := g.sentinel(srcSlice.Elem(), srcName, 1); sentinel != nil {
+		elem = sentinel.value
+	}
+	if isPointer(srcSlice.Elem()) {
+		elem = New(g.genType(unwrapPointer(srcSlice.Elem())))
+	}
+	if elem != nil {
+		setup = append(setup, For(Id("i").Op(":=").Range().Id(srcName)).Block(
+			Id(srcName).Index(Id("i")).Op("=").Add(elem),
+		))
+	}
+	return g.benchmarkLoop(mf, setup, call)
+}
+
+// benchmarkLoop returns the setup statements followed by the call of the
+// mapping in the benchmark loop. A destination parameter is declared once,
+// outside of the loop.
+func (g *Generator) benchmarkLoop(mf *mappingFunc, setup, call []Code) []Code {
+	if !mf.dstConstructed {
+		setup = append(setup, call[0])
+		call = call[1:]
+	}
+	return append(setup,
+		Id("b").Dot("ReportAllocs").Call(),
+		Id("b").Dot("ResetTimer").Call(),
+		For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("b").Dot("N"), Id("i").Op("++")).Block(call...),
+	)
+}
+
+// fillSource returns a sentinel value for the source field with the type
+// ty, selected through the parents, and the statements assigning it. It
+// returns nil if the field has no sentinel.
+func (g *Generator) fillSource(srcName string, parents []mapper.Field, name string, ty types.Type, n int, allocated map[string]bool) (*sentinelValue, []Code) {
+	elem := unwrapPointer(ty)
+	path := []string{}
+	for _, parent := range parents {
+		path = append(path, parent.Name())
+	}
+	path = append(path, name)
+	sentinel := g.sentinel(elem, srcName+"."+strings.Join(path, "."), n)
+	if sentinel == nil {
+		return nil, nil
+	}
+	value := sentinel.value.Clone()
+	if isPointer(ty) && !isDefaultType(elem) {
+		value = g.genType(elem).Call(value)
+	}
+	stmts := g.assignSource(srcName, parents, name, ty, value, allocated)
+	if stmts == nil {
+		return nil, nil
+	}
+	return sentinel, stmts
+}
+
+// assignSource returns the statements assigning value to the source field
+// with the type ty, selected through the parents, allocating the parents
+// that are pointers. Pointer fields are assigned the address of a copy of
+// value, which must then be typed. It returns nil if a parent can not be
+// allocated.
+func (g *Generator) assignSource(srcName string, parents []mapper.Field, name string, ty types.Type, value *Statement, allocated map[string]bool) []Code {
+	stmts := []Code{}
+	sel := Id(srcName)
+	v := srcName
+	for _, parent := range parents {
+		sel = sel.Clone().Dot(parent.Name())
+		v += parent.Name()
+		if !isPointer(parent.Type()) {
+			continue
+		}
+		parentElem := unwrapPointer(parent.Type())
+		if !g.nameable(parentElem) {
+			return nil
+		}
+		key := fmt.Sprintf("%#v", sel)
+		if !allocated[key] {
+			allocated[key] = true
+			stmts = append(stmts, sel.Clone().Op("=").New(g.genType(parentElem)))
+		}
+	}
+	sel = sel.Clone().Dot(name)
+	v += name
+
+	if !isPointer(ty) {
+		return append(stmts, sel.Op("=").Add(value))
+	}
+	return append(stmts,
+		Id(v).Op(":=").Add(value),
+		sel.Op("=").Op("&").Id(v),
+	)
+}
+
+// checkDestination checks the destination field of the pair holds the
+// sentinel.
+func (g *Generator) checkDestination(dstName string, p mapper.FieldPair, sentinel *sentinelValue) Code {
+	path := dstName + "." + p.Destination.Path()
+	got := Id(dstName)
+	for _, parent := range p.Destination.Parents() {
+		got = got.Dot(parent.Name())
+	}
+	got = got.Dot(p.Destination.Name())
+	return g.checkValue(got, path, p.Destination.Type(), sentinel)
+}
+
+// checkValue checks the value got of type ty, described by path, holds the
+// sentinel.
+func (g *Generator) checkValue(got *Statement, path string, ty types.Type, sentinel *sentinelValue) Code {
+	want := sentinel.value
+	// booleans are checked without comparing to a constant
+	differs := func(got *Statement) *Statement {
+		if isBoolean(unwrapPointer(ty)) {
+			if sentinel.truth {
+				return Op("!").Add(got)
+			}
+			return got
+		}
+		return got.Op("!=").Add(want.Clone())
+	}
+
+	switch {
+	case isPointer(ty):
+		return If(got.Clone().Op("==").Nil().Op("||").Add(differs(Op("*").Add(got.Clone())))).Block(
+			Id("t").Dot("Errorf").Call(Lit(path+" does not point to %#v"), want.Clone()),
+		)
+	case isBasic(ty):
+		return If(differs(got.Clone())).Block(
+			Id("t").Dot("Errorf").Call(Lit(path+" = %#v, want %#v"), got.Clone(), want.Clone()),
+		)
+	}
+	return If(Op("!").Qual("reflect", "DeepEqual").Call(got.Clone(), want.Clone())).Block(
+		Id("t").Dot("Errorf").Call(Lit(path+" = %#v, want %#v"), got.Clone(), want.Clone()),
+	)
+}
+
+// sentinelValue is a value checked in the destination of a generated test,
+// with its truth if it is a boolean.
+type sentinelValue struct {
+	value *Statement
+	truth bool
+}
+
+// sentinel returns a value of ty that is distinct for the field with the
+// path and number n, an untyped constant for basic types. It returns nil if
+// there is no such value.
+func (g *Generator) sentinel(ty types.Type, path string, n int) *sentinelValue {
+	if !g.nameable(ty) {
+		return nil
+	}
+	switch u := ty.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsString != 0:
+			return &sentinelValue{value: Lit(fmt.Sprintf("%s %d", path, n))}
+		case info&types.IsBoolean != 0:
+			// alternate so swapped fields are detected
+			return &sentinelValue{value: Lit(n%2 == 1), truth: n%2 == 1}
+		case info&types.IsInteger != 0:
+			return &sentinelValue{value: Lit(intSentinel(u.Kind(), n))}
+		case info&types.IsFloat != 0:
+			return &sentinelValue{value: Lit(float64(n) + 0.5)}
+		}
+	case *types.Slice:
+		if elem := g.sentinel(u.Elem(), path, n); elem != nil {
+			return &sentinelValue{value: g.genType(ty).Values(elem.value)}
+		}
+	case *types.Map:
+		key := g.sentinel(u.Key(), path, n)
+		elem := g.sentinel(u.Elem(), path, n)
+		if key != nil && elem != nil {
+			return &sentinelValue{value: g.genType(ty).Values(Dict{key.value: elem.value})}
+		}
+	}
+	return nil
+}
+
+// intSentinel returns n, wrapped from 1 to the largest value of the integer
+// kind so the constant does not overflow it. int, uint and uintptr are at
+// least 32 bits.
+func intSentinel(kind types.BasicKind, n int) int {
+	max := math.MaxInt32
+	switch kind {
+	case types.Int8:
+		max = math.MaxInt8
+	case types.Uint8:
+		max = math.MaxUint8
+	case types.Int16:
+		max = math.MaxInt16
+	case types.Uint16:
+		max = math.MaxUint16
+	}
+	return (n-1)%max + 1
+}
+
+// sentinelComparable reports if a sentinel of srcType can be compared to a
+// value of dstType, basic types of the same kind or identical types.
+func (g *Generator) sentinelComparable(srcType, dstType types.Type) bool {
+	if isPointer(srcType) || isPointer(dstType) {
+		return false
+	}
+	srcBasic, srcOk := srcType.Underlying().(*types.Basic)
+	dstBasic, dstOk := dstType.Underlying().(*types.Basic)
+	if srcOk && dstOk {
+		const kinds = types.IsString | types.IsBoolean | types.IsInteger | types.IsFloat
+		return srcBasic.Info()&kinds == dstBasic.Info()&kinds
+	}
+	return types.Identical(srcType, dstType)
+}
+
+// nameable reports if the type can be written in the generated package.
+func (g *Generator) nameable(ty types.Type) bool {
+	switch ty := ty.(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		return g.nameable(ty.Elem())
+	case *types.Slice:
+		return g.nameable(ty.Elem())
+	case *types.Array:
+		return g.nameable(ty.Elem())
+	case *types.Map:
+		return g.nameable(ty.Key()) && g.nameable(ty.Elem())
+	case *types.Named:
+		obj := ty.Obj()
+		if obj.Pkg() != nil && obj.Pkg() != g.ssapkg.Pkg && !obj.Exported() {
+			return false
+		}
+		for i := 0; i < ty.TypeArgs().Len(); i++ {
+			if !g.nameable(ty.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isBasic(ty types.Type) bool {
+	_, ok := ty.Underlying().(*types.Basic)
+	return ok
+}
+
+func isBoolean(ty types.Type) bool {
+	b, ok := ty.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsBoolean != 0
+}
+
+// isDefaultType reports if ty is the default type of the sentinel constants,
+// so they can be assigned without conversion.
+func isDefaultType(ty types.Type) bool {
+	b, ok := ty.(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch b.Kind() {
+	case types.String, types.Bool, types.Int, types.Float64:
+		return true
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
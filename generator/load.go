@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"go/types"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Load loads and builds the declaration package in dir with the typemapper
+// build tag, ready to pass to NewGenerator.
+func Load(dir string) (*ssa.Package, error) {
+	cfg := &packages.Config{
+		Dir:        dir,
+		Mode:       packages.LoadAllSyntax,
+		BuildFlags: []string{"-tags", BuildTag, "-a"},
+		Tests:      false,
+	}
+	pkgs, err := packages.Load(cfg, "./")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(pkgs) != 1 {
+		return nil, errors.Errorf("error: %d packages found", len(pkgs))
+	}
+	pkg := pkgs[0]
+
+	prog := ssa.NewProgram(pkg.Fset, ssa.BuilderMode(0))
+
+	// Create SSA packages for all imports.
+	// Order is not significant.
+	created := make(map[*types.Package]bool)
+	var createAll func(pkgs []*types.Package)
+	createAll = func(pkgs []*types.Package) {
+		for _, p := range pkgs {
+			if !created[p] {
+				created[p] = true
+				prog.CreatePackage(p, nil, nil, true)
+				createAll(p.Imports())
+			}
+		}
+	}
+	createAll(pkg.Types.Imports())
+
+	// Create and build the primary package.
+	ssapkg := prog.CreatePackage(pkg.Types, pkg.Syntax, pkg.TypesInfo, false)
+	ssapkg.Build()
+
+	return ssapkg, nil
+}
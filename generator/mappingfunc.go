@@ -1,8 +1,12 @@
 package generator
 
 import (
+	"fmt"
 	"go/types"
+	"regexp"
+	"strings"
 
+	"github.com/pkg/errors"
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/paultyng/go-typemapper/mapper"
@@ -17,6 +21,11 @@ type mappingFunc struct {
 	srcType     types.Type
 	srcReceiver bool
 
+	// params are the parameters of the function, excluding the receiver,
+	// srcParam is the index of the source or -1 if it is the receiver
+	params   []*types.Var
+	srcParam int
+
 	dstName        string
 	dstType        types.Type
 	dstConstructed bool
@@ -24,27 +33,256 @@ type mappingFunc struct {
 
 	errReturned bool
 
+	// addedSrcs are the sources added with AddSource, in priority order
+	addedSrcs []mappingSource
+
 	prefixes   []string
 	ignores    []string
-	manualMaps map[string]string
+	only       []string
+	manualMaps map[string]sourceField
+	valueMaps  map[string]sourceValue
 	mapWith    []*ssa.Function
+
+	// ignorePatterns and ignoreTypes are the names and types of fields
+	// ignored on both sides
+	ignorePatterns []*regexp.Regexp
+	ignoreTypes    []types.Type
+
+	// ignoreSrcs are source fields ignored with IgnoreSourceFields
+	ignoreSrcs []sourceField
+
+	disableAutoMapWith bool
+	transitive         bool
+	deepCopy           bool
+	strict             bool
+	minimizeAllocs     bool
+
+	// chain is set for conversions composed of several mapping functions.
+	chain mappingCache
+
+	// mapWithFuncs are converters that are not mapping functions in the
+	// package, for example function parameters passed to MapWith.
+	mapWithFuncs mappingCache
+
+	// converters are the functions of the packages passed to UseConverters.
+	converters mappingCache
 }
 
 func (mf *mappingFunc) MapWith(cache mappingCache) mappingCache {
 	mw := mappingCache{}
 	for _, mwf := range mf.mapWith {
+		var found *mappingFunc
 		for _, c := range cache {
 			if c.fn == mwf {
-				mw = append(mw, c)
+				found = c
 				break
 			}
 		}
+		if found == nil {
+			// not a mapping function in this package, use its signature
+			found = funcMappingFunc(mwf)
+		}
+		if found != nil {
+			mw = append(mw, found)
+		}
+	}
+	return append(mw, mf.mapWithFuncs...)
+}
+
+// contextParam returns the parameter of the mapping function, other than the
+// source and destination, of the type ty so it can be passed as a context
+// argument. Types must be identical, so interface parameters are not passed
+// for anything they can hold. Several parameters of the type are an error.
+func (mf *mappingFunc) contextParam(ty types.Type) (*types.Var, error) {
+	var found *types.Var
+	for i, p := range mf.params {
+		if i == mf.srcParam || p.Name() == mf.srcName {
+			continue
+		}
+		if !mf.dstConstructed && p.Name() == mf.dstName {
+			continue
+		}
+		if !types.Identical(p.Type(), ty) {
+			continue
+		}
+		if found != nil {
+			return nil, errors.Errorf("ambiguous parameters of %s of type %s: %s, %s", mf.name, ty, found.Name(), p.Name())
+		}
+		found = p
 	}
-	return mw
+	return found, nil
+}
+
+// localName returns name, with a numeric suffix if the mapping function
+// already has a receiver, parameter, source or destination with the name, so
+// a local variable of the generated code does not shadow it.
+func (mf *mappingFunc) localName(name string) string {
+	used := map[string]bool{mf.dstName: true}
+	for _, srcName := range mf.sourceNames() {
+		used[srcName] = true
+	}
+	if mf.fn != nil {
+		for _, p := range mf.fn.Params {
+			used[p.Name()] = true
+		}
+	}
+	local := name
+	for i := 1; used[local]; i++ {
+		local = fmt.Sprintf("%s%d", name, i)
+	}
+	return local
+}
+
+type mappingSource struct {
+	name string
+	ty   types.Type
+}
+
+type sourceField struct {
+	source int
+	name   string
+}
+
+// sourceNames returns the names of all the sources in priority order.
+func (mf *mappingFunc) sourceNames() []string {
+	srcName := mf.srcName
+	if srcName == "" {
+		srcName = defaultSrcName
+	}
+	names := []string{srcName}
+	for _, src := range mf.addedSrcs {
+		names = append(names, src.name)
+	}
+	return names
+}
+
+// declaredTypes returns the types of the receiver and parameters by name,
+// as declared in the signature, CreateMap may dereference them.
+func (mf *mappingFunc) declaredTypes() map[string]types.Type {
+	declared := map[string]types.Type{}
+	if recv := mf.fn.Signature.Recv(); recv != nil {
+		declared[recv.Name()] = recv.Type()
+	}
+	for _, p := range mf.params {
+		declared[p.Name()] = p.Type()
+	}
+	return declared
+}
+
+// sourceType returns the type of the source at index i, in priority order.
+func (mf *mappingFunc) sourceType(i int) types.Type {
+	if i == 0 {
+		return mf.srcType
+	}
+	return mf.addedSrcs[i-1].ty
+}
+
+// sourceIndex returns the index of the source whose field is selected by v,
+// or -1. Sources are found by the parameter v selects from, so sources of
+// the same type are told apart, otherwise by the field.
+func (mf *mappingFunc) sourceIndex(v ssa.Value, field *types.Var) int {
+	if p := selectorParam(v); p != nil {
+		for i, name := range mf.sourceNames() {
+			if name == p.Name() {
+				return i
+			}
+		}
+		return -1
+	}
+
+	srcTypes := []types.Type{mf.srcType}
+	for _, src := range mf.addedSrcs {
+		srcTypes = append(srcTypes, src.ty)
+	}
+
+	for _, identical := range []bool{true, false} {
+		for i, srcType := range srcTypes {
+			// fields may be promoted from embedded structs
+			obj, _, _ := types.LookupFieldOrMethod(srcType, true, field.Pkg(), field.Name())
+			f, ok := obj.(*types.Var)
+			if !ok {
+				continue
+			}
+			if identical && f == field {
+				return i
+			}
+			// instantiated generic types may not share field objects
+			if !identical && types.Identical(f.Type(), field.Type()) {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 type mappingCache []*mappingFunc
 
+// converters returns the mapping functions able to convert srcType to dstType.
+func (mc mappingCache) converters(srcType, dstType types.Type) mappingCache {
+	matches := mappingCache{}
+	for _, mw := range mc {
+		if mw.returns(dstType) && mw.accepts(srcType) {
+			matches = append(matches, mw)
+		}
+	}
+	return matches
+}
+
+// callableFrom returns the mapping functions whose context arguments can be
+// passed from the parameters of the mapping mf.
+func (mc mappingCache) callableFrom(mf *mappingFunc) mappingCache {
+	callable := mappingCache{}
+	for _, mw := range mc {
+		ok := true
+		for i, p := range mw.params {
+			if i == mw.srcParam {
+				continue
+			}
+			// ambiguous parameters are reported when calling mw
+			if ctx, err := mf.contextParam(p.Type()); ctx == nil && err == nil {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			callable = append(callable, mw)
+		}
+	}
+	return callable
+}
+
+func (mc mappingCache) names(sep string) string {
+	names := make([]string, 0, len(mc))
+	for _, mf := range mc {
+		names = append(names, mf.name)
+	}
+	return strings.Join(names, sep)
+}
+
+// accepts reports if a value of srcType can be passed as the source of the
+// mapping function.
+func (mf *mappingFunc) accepts(srcType types.Type) bool {
+	if types.AssignableTo(srcType, mf.srcType) {
+		return true
+	}
+
+	// TODO: handle multiple srcTypes when pointer receiver type?
+	return mf.srcReceiver && isPointer(mf.srcType) && types.AssignableTo(srcType, unwrapPointer(mf.srcType))
+}
+
+// returns reports if the result of the mapping function can be used for dstType.
+func (mf *mappingFunc) returns(dstType types.Type) bool {
+	return types.AssignableTo(dstType, mf.dstType)
+}
+
+// converter returns the first mapping function able to convert srcType to dstType.
+func (mc mappingCache) converter(srcType, dstType types.Type) *mappingFunc {
+	if matches := mc.converters(srcType, dstType); len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
 func (mf *mappingFunc) SliceMapping() bool {
 	src := unwrapSlice(mf.srcType)
 	dst := unwrapSlice(mf.dstType)
@@ -57,22 +295,50 @@ func (mf *mappingFunc) StructMapping() bool {
 	return src != nil && dst != nil
 }
 
-func (mf *mappingFunc) Mapper() *mapper.StructMapper {
+func (mf *mappingFunc) Mapper(convertible func(src, dst types.Type) bool) *mapper.StructMapper {
 	m := mapper.NewStructMapper(mf.srcType, mf.dstType)
 	if m == nil {
 		return nil
 	}
 
+	if convertible != nil {
+		m = m.ConvertTypes(convertible)
+	}
+
 	if len(mf.prefixes) > 0 {
 		m = m.RecognizePrefixes(mf.prefixes...)
 	}
 	if len(mf.ignores) > 0 {
 		m = m.IgnoreFields(mf.ignores...)
 	}
+	if len(mf.ignorePatterns) > 0 {
+		m = m.IgnoreFieldsMatching(mf.ignorePatterns...)
+	}
+	if len(mf.ignoreTypes) > 0 {
+		m = m.IgnoreFieldsOfType(mf.ignoreTypes...)
+	}
+	if len(mf.only) > 0 {
+		m = m.OnlyFields(mf.only...)
+	}
+	for _, src := range mf.addedSrcs {
+		m = m.AddSource(src.ty)
+		if m == nil {
+			return nil
+		}
+	}
 	if len(mf.manualMaps) > 0 {
 		for dst, src := range mf.manualMaps {
-			m = m.MapField(src, dst)
+			m = m.MapSourceField(src.source, src.name, dst)
 		}
 	}
+	for dst, src := range mf.valueMaps {
+		m = m.MapValue(fmt.Sprintf("%#v", src.code), src.ty, dst)
+		for _, used := range src.uses {
+			m = m.UseSourceFields(used.source, used.name)
+		}
+	}
+	for _, src := range mf.ignoreSrcs {
+		m = m.IgnoreSourceFields(src.source, src.name)
+	}
 	return m
 }
@@ -3,6 +3,8 @@ package generator
 import (
 	"go/token"
 	"go/types"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -54,8 +56,13 @@ func (g *Generator) parseFunction(f *ssa.Function) (*mappingFunc, error) {
 					if err != nil {
 						return nil, errors.WithStack(err)
 					}
+				case "AddSource":
+					err = handleAddSource(m, inst)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
 				case "MapField":
-					err = handleMapField(m, inst)
+					err = g.handleMapField(m, inst)
 					if err != nil {
 						return nil, errors.WithStack(err)
 					}
@@ -64,11 +71,51 @@ func (g *Generator) parseFunction(f *ssa.Function) (*mappingFunc, error) {
 					if err != nil {
 						return nil, errors.WithStack(err)
 					}
+				case "IgnoreFieldsMatching":
+					err = handleIgnoreFieldsMatching(m, inst, globRegexp)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
+				case "IgnoreFieldsMatchingRegexp":
+					err = handleIgnoreFieldsMatching(m, inst, regexp.Compile)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
+				case "IgnoreFieldsOfType":
+					err = handleIgnoreFieldsOfType(m, inst)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
+				case "IgnoreSourceFields":
+					err = handleIgnoreSourceFields(m, inst)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
+				case "Strict":
+					m.strict = true
+				case "MinimizeAllocs":
+					m.minimizeAllocs = true
+				case "OnlyFields":
+					err = handleOnlyFields(m, inst)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
 				case "MapWith":
 					err = handleMapWith(m, inst)
 					if err != nil {
 						return nil, errors.WithStack(err)
 					}
+				case "DisableAutoMapWith":
+					m.disableAutoMapWith = true
+				case "MapTransitively":
+					m.transitive = true
+				case "DeepCopy":
+					m.deepCopy = true
+				case "UseConverters":
+					err = handleUseConverters(g.ssapkg.Prog, m, inst)
+					if err != nil {
+						return nil, errors.WithStack(err)
+					}
 				}
 			}
 		}
@@ -195,24 +242,77 @@ func call(v ssa.Value) (*ssa.Call, error) {
 	}
 }
 
-func field(v ssa.Value) (*types.Var, error) {
+// fieldPath returns the fields selected by v, outermost first, so
+// [Address, City] for `src.Address.City`.
+func fieldPath(v ssa.Value) ([]*types.Var, error) {
+	if mi, ok := v.(*ssa.MakeInterface); ok {
+		v = mi.X
+	}
+	path := selectorPath(v)
+	if len(path) == 0 {
+		return nil, errors.Errorf("unexpected field value type %T %#v", v, v)
+	}
+	return path, nil
+}
+
+// selectorPath returns the fields selected by v, or nil if v does not
+// select a field.
+func selectorPath(v ssa.Value) []*types.Var {
+	var (
+		x     ssa.Value
+		index int
+	)
 	switch v := v.(type) {
 	default:
-		return nil, errors.Errorf("unexpected field value type %T %#v", v, v)
-	case *ssa.MakeInterface:
-		return field(v.X)
+		return nil
 	case *ssa.UnOp:
-		return field(v.X)
+		if v.Op != token.MUL {
+			return nil
+		}
+		return selectorPath(v.X)
 	case *ssa.FieldAddr:
-		st, err := structType(v.X.Type())
-		if err != nil {
-			return nil, errors.WithStack(err)
+		x, index = v.X, v.Field
+	case *ssa.Field:
+		x, index = v.X, v.Field
+	}
+	st, err := structType(x.Type())
+	if err != nil {
+		return nil
+	}
+	return append(selectorPath(x), st.Field(index))
+}
+
+// selectorParam returns the parameter whose fields v selects, or nil.
+func selectorParam(v ssa.Value) *ssa.Parameter {
+	switch v := v.(type) {
+	case *ssa.MakeInterface:
+		return selectorParam(v.X)
+	case *ssa.UnOp:
+		if v.Op == token.MUL {
+			return selectorParam(v.X)
 		}
-		return st.Field(v.Field), nil
+	case *ssa.FieldAddr:
+		return selectorParam(v.X)
+	case *ssa.Field:
+		return selectorParam(v.X)
+	case *ssa.Parameter:
+		return v
+	case *ssa.Alloc:
+		return allocParam(v)
 	}
+	return nil
 }
 
-func callInterfaceSlice(v ssa.Value) ([]*ssa.Call, error) {
+func pathString(path []*types.Var) string {
+	names := make([]string, 0, len(path))
+	for _, f := range path {
+		names = append(names, f.Name())
+	}
+	return strings.Join(names, ".")
+}
+
+// interfaceSlice returns the values stored in a variadic interface{} slice.
+func interfaceSlice(v ssa.Value) ([]ssa.Value, error) {
 	sli, ok := v.(*ssa.Slice)
 	if !ok {
 		return nil, errors.Errorf("expected value of type Slice, got %T", v)
@@ -222,30 +322,19 @@ func callInterfaceSlice(v ssa.Value) ([]*ssa.Call, error) {
 		return nil, errors.Errorf("expected value of type Alloc, got %T", v)
 	}
 
-	var (
-		err    error
-		values []*ssa.Call
-	)
+	var values []ssa.Value
 	walkReferrers(alloc, func(inst ssa.Instruction) bool {
 		switch inst := inst.(type) {
 		case *ssa.Store:
-			var c *ssa.Call
-			c, err = call(inst.Val)
-			if err != nil {
-				return false
-			}
-			values = append(values, c)
+			values = append(values, inst.Val)
 		}
 		return true
 	})
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
 
 	return values, nil
 }
 
-func fieldInterfaceSlice(v ssa.Value) ([]*types.Var, error) {
+func fieldInterfaceSlice(v ssa.Value) ([][]*types.Var, error) {
 	sli, ok := v.(*ssa.Slice)
 	if !ok {
 		return nil, errors.Errorf("expected value of type Slice, got %T", v)
@@ -257,13 +346,13 @@ func fieldInterfaceSlice(v ssa.Value) ([]*types.Var, error) {
 
 	var (
 		err    error
-		values []*types.Var
+		values [][]*types.Var
 	)
 	walkReferrers(alloc, func(inst ssa.Instruction) bool {
 		switch inst := inst.(type) {
 		case *ssa.Store:
-			var v *types.Var
-			v, err = field(inst.Val)
+			var v []*types.Var
+			v, err = fieldPath(inst.Val)
 			if err != nil {
 				return false
 			}
@@ -278,36 +367,230 @@ func fieldInterfaceSlice(v ssa.Value) ([]*types.Var, error) {
 	return values, nil
 }
 
-func handleMapField(m *mappingFunc, call ssa.CallInstruction) error {
+func (g *Generator) handleMapField(m *mappingFunc, call ssa.CallInstruction) error {
 	if argLen := len(call.Common().Args); argLen != 2 {
 		return errors.Errorf("expected 2 args for MapField, found %d", argLen)
 	}
-	srcField, err := field(call.Common().Args[0])
+	dstPath, err := fieldPath(call.Common().Args[1])
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	dstField, err := field(call.Common().Args[1])
+
+	srcPath, err := fieldPath(call.Common().Args[0])
 	if err != nil {
-		return errors.WithStack(err)
+		// not a field, so re-emit the source expression
+		srcValue := call.Common().Args[0]
+		if mi, ok := srcValue.(*ssa.MakeInterface); ok {
+			srcValue = mi.X
+		}
+		code, exprErr := g.expression(srcValue)
+		if exprErr != nil {
+			return errors.Wrapf(exprErr, "unable to map %s from MapField source", pathString(dstPath))
+		}
+		value := sourceValue{
+			code: code,
+			ty:   srcValue.Type(),
+		}
+		for _, sel := range selectors(srcValue) {
+			path := selectorPath(sel)
+			if source := m.sourceIndex(sel, path[0]); source >= 0 {
+				value.uses = append(value.uses, sourceField{
+					source: source,
+					name:   pathString(path),
+				})
+			}
+		}
+		m.valueMaps[pathString(dstPath)] = value
+		return nil
+	}
+
+	source := m.sourceIndex(call.Common().Args[0], srcPath[0])
+	if source < 0 {
+		return errors.Errorf("MapField source %s is not a field of a source of the map", pathString(srcPath))
+	}
+	m.manualMaps[pathString(dstPath)] = sourceField{
+		source: source,
+		name:   pathString(srcPath),
 	}
-	m.manualMaps[dstField.Name()] = srcField.Name()
 	return nil
 }
 
-func handleMapWith(m *mappingFunc, call ssa.CallInstruction) error {
+func handleAddSource(m *mappingFunc, call ssa.CallInstruction) error {
 	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for AddSource, found %d", argLen)
+	}
+	name, ty, constructed, err := param(call, call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if constructed || name == "" {
+		return errors.Errorf("AddSource expects a parameter of the mapping function")
+	}
+	m.addedSrcs = append(m.addedSrcs, mappingSource{
+		name: name,
+		ty:   ty,
+	})
+	return nil
+}
+
+func handleMapWith(m *mappingFunc, inst ssa.CallInstruction) error {
+	if argLen := len(inst.Common().Args); argLen != 1 {
 		return errors.Errorf("expected 1 arg for MapWith, found %d", argLen)
 	}
-	calls, err := callInterfaceSlice(call.Common().Args[0])
+	values, err := interfaceSlice(inst.Common().Args[0])
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	for _, c := range calls {
-		m.mapWith = append(m.mapWith, c.Call.StaticCallee())
+	for _, v := range values {
+		var fn *ssa.Function
+		if mi, ok := v.(*ssa.MakeInterface); ok {
+			switch x := mi.X.(type) {
+			case *ssa.Parameter:
+				mw, err := paramMappingFunc(x)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				m.mapWithFuncs = append(m.mapWithFuncs, mw)
+				continue
+			case *ssa.Function:
+				fn = x
+			}
+		}
+
+		if fn == nil {
+			c, err := call(v)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			fn = c.Call.StaticCallee()
+		}
+		if funcMappingFunc(fn) == nil {
+			return errors.Errorf("MapWith function %s must take a source and return a destination, found %s", fn.Name(), fn.Signature)
+		}
+		m.mapWith = append(m.mapWith, fn)
 	}
 	return nil
 }
 
+// funcMappingFunc describes an existing function or method with a signature
+// usable for type conversions, for example one from another package. It
+// returns nil if the signature does not fit.
+func funcMappingFunc(fn *ssa.Function) *mappingFunc {
+	m := signatureMappingFunc(fn.Signature)
+	if m == nil {
+		return nil
+	}
+	m.fn = fn
+	m.name = fn.Name()
+	return m
+}
+
+// signatureMappingFunc describes a converter from its signature. Methods
+// convert their receiver, functions convert their last parameter, any other
+// parameters are context arguments passed from the calling mapping function.
+func signatureMappingFunc(sig *types.Signature) *mappingFunc {
+	if sig.TypeParams().Len() > 0 || sig.Results().Len() != 1 || sig.Variadic() {
+		return nil
+	}
+
+	m := &mappingFunc{
+		dstType:        sig.Results().At(0).Type(),
+		dstConstructed: true,
+		dstReturned:    true,
+		srcParam:       -1,
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		m.params = append(m.params, sig.Params().At(i))
+	}
+
+	switch recv := sig.Recv(); {
+	case recv != nil:
+		m.srcType = recv.Type()
+		m.srcReceiver = true
+	case len(m.params) > 0:
+		m.srcParam = len(m.params) - 1
+		m.srcType = m.params[m.srcParam].Type()
+	default:
+		return nil
+	}
+
+	return m
+}
+
+func handleUseConverters(prog *ssa.Program, m *mappingFunc, call ssa.CallInstruction) error {
+	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for UseConverters, found %d", argLen)
+	}
+	pkgPaths, err := literalStringSlice(call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, pkgPath := range pkgPaths {
+		pkg := prog.ImportedPackage(pkgPath)
+		if pkg == nil {
+			return errors.Errorf("unable to find converter package %q, it must be imported", pkgPath)
+		}
+		m.converters = append(m.converters, converters(prog, pkg)...)
+	}
+	return nil
+}
+
+// converters returns the exported functions and methods of the package that
+// can convert between types that are not already assignable.
+func converters(prog *ssa.Program, pkg *ssa.Package) mappingCache {
+	fns := []*ssa.Function{}
+	for _, mem := range pkg.Members {
+		if obj := mem.Object(); obj == nil || !obj.Exported() {
+			continue
+		}
+		switch mem := mem.(type) {
+		case *ssa.Function:
+			fns = append(fns, mem)
+		case *ssa.Type:
+			named, ok := mem.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			for i := 0; i < named.NumMethods(); i++ {
+				if method := named.Method(i); method.Exported() {
+					fns = append(fns, prog.FuncValue(method))
+				}
+			}
+		}
+	}
+
+	mc := mappingCache{}
+	for _, fn := range fns {
+		if fn == nil {
+			continue
+		}
+		mf := funcMappingFunc(fn)
+		if mf == nil || types.AssignableTo(mf.srcType, mf.dstType) {
+			continue
+		}
+		mc = append(mc, mf)
+	}
+	sort.Slice(mc, func(i, j int) bool {
+		return mc[i].fn.String() < mc[j].fn.String()
+	})
+	return mc
+}
+
+// paramMappingFunc describes a function parameter passed to MapWith, such as
+// the element mapping of a generic mapping function.
+func paramMappingFunc(p *ssa.Parameter) (*mappingFunc, error) {
+	sig, ok := p.Type().Underlying().(*types.Signature)
+	if !ok {
+		return nil, errors.Errorf("MapWith parameter %s is not a function, found %s", p.Name(), p.Type())
+	}
+	m := signatureMappingFunc(sig)
+	if m == nil {
+		return nil, errors.Errorf("MapWith parameter %s must take a source and return a destination, found %s", p.Name(), sig)
+	}
+	m.name = p.Name()
+	return m, nil
+}
+
 func handleRecognizePrefixes(m *mappingFunc, call ssa.CallInstruction) error {
 	if argLen := len(call.Common().Args); argLen != 1 {
 		return errors.Errorf("expected 1 arg for RecognizePrefixes, found %d", argLen)
@@ -330,12 +613,98 @@ func handleIgnoreFields(m *mappingFunc, call ssa.CallInstruction) error {
 	}
 	ignoreNames := make([]string, 0, len(ignores))
 	for _, ig := range ignores {
-		ignoreNames = append(ignoreNames, ig.Name())
+		ignoreNames = append(ignoreNames, pathString(ig))
 	}
 	m.ignores = append(m.ignores, ignoreNames...)
 	return nil
 }
 
+func handleIgnoreFieldsMatching(m *mappingFunc, call ssa.CallInstruction, compile func(string) (*regexp.Regexp, error)) error {
+	// IgnoreFieldsMatching or IgnoreFieldsMatchingRegexp
+	name := call.Common().StaticCallee().Name()
+	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for %s, found %d", name, argLen)
+	}
+	patterns, err := literalStringSlice(call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, p := range patterns {
+		re, err := compile(p)
+		if err != nil {
+			return errors.Wrapf(err, "invalid field pattern %q for %s", p, name)
+		}
+		m.ignorePatterns = append(m.ignorePatterns, re)
+	}
+	return nil
+}
+
+// globRegexp compiles a glob pattern, where * matches any characters and ?
+// matches a single character, to a regular expression matching whole names.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.Compile("^" + expr + "$")
+}
+
+func handleIgnoreFieldsOfType(m *mappingFunc, call ssa.CallInstruction) error {
+	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for IgnoreFieldsOfType, found %d", argLen)
+	}
+	values, err := interfaceSlice(call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, v := range values {
+		mi, ok := v.(*ssa.MakeInterface)
+		if !ok {
+			return errors.Errorf("unexpected IgnoreFieldsOfType value %T %#v", v, v)
+		}
+		m.ignoreTypes = append(m.ignoreTypes, mi.X.Type())
+	}
+	return nil
+}
+
+func handleIgnoreSourceFields(m *mappingFunc, call ssa.CallInstruction) error {
+	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for IgnoreSourceFields, found %d", argLen)
+	}
+	values, err := interfaceSlice(call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, v := range values {
+		ig, err := fieldPath(v)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		source := m.sourceIndex(v, ig[0])
+		if source < 0 {
+			return errors.Errorf("IgnoreSourceFields field %s is not a field of a source of the map", pathString(ig))
+		}
+		m.ignoreSrcs = append(m.ignoreSrcs, sourceField{
+			source: source,
+			name:   pathString(ig),
+		})
+	}
+	return nil
+}
+
+func handleOnlyFields(m *mappingFunc, call ssa.CallInstruction) error {
+	if argLen := len(call.Common().Args); argLen != 1 {
+		return errors.Errorf("expected 1 arg for OnlyFields, found %d", argLen)
+	}
+	only, err := fieldInterfaceSlice(call.Common().Args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, f := range only {
+		m.only = append(m.only, pathString(f))
+	}
+	return nil
+}
+
 func handleCreateMap(call ssa.CallInstruction, f *ssa.Function) (*mappingFunc, error) {
 	var err error
 	m := &mappingFunc{
@@ -343,9 +712,12 @@ func handleCreateMap(call ssa.CallInstruction, f *ssa.Function) (*mappingFunc, e
 		name: f.Name(),
 
 		ignores:    []string{},
-		manualMaps: map[string]string{},
+		manualMaps: map[string]sourceField{},
+		valueMaps:  map[string]sourceValue{},
 		prefixes:   []string{},
 		mapWith:    []*ssa.Function{},
+
+		mapWithFuncs: mappingCache{},
 	}
 
 	src := call.Common().Args[0]
@@ -363,10 +735,20 @@ func handleCreateMap(call ssa.CallInstruction, f *ssa.Function) (*mappingFunc, e
 		return nil, errors.Errorf("unable to determine destination type, %T %#v", dst, dst)
 	}
 
+	m.srcParam = -1
+	for i := 0; i < f.Signature.Params().Len(); i++ {
+		p := f.Signature.Params().At(i)
+		if p.Name() == m.srcName {
+			m.srcParam = i
+		}
+		m.params = append(m.params, p)
+	}
+
 	if recv := f.Signature.Recv(); recv != nil {
 		// TODO: validate that its src type / name?
 		// for now just assuming if there is a receiver its the src
 		m.srcReceiver = true
+		m.srcParam = -1
 	}
 
 	results := f.Signature.Results()
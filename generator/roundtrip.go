@@ -0,0 +1,279 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+
+	"github.com/paultyng/go-typemapper/mapper"
+)
+
+// generateRoundTrips generates a round-trip test for each pair of struct
+// mappings that are the inverse of each other.
+func (g *Generator) generateRoundTrips() error {
+	for i, mf := range g.cache {
+		inverses := mappingCache{}
+		for _, inverse := range g.cache[i+1:] {
+			if roundTrip(mf, inverse) {
+				inverses = append(inverses, inverse)
+			}
+		}
+		for _, inverse := range inverses {
+			// name the test after both mappings if mf has several inverses
+			name := fmt.Sprintf("Test%sRoundTrip", mf.name)
+			if len(inverses) > 1 {
+				name = fmt.Sprintf("Test%s%sRoundTrip", mf.name, inverse.name)
+			}
+			err := g.generateRoundTripTest(name, mf, inverse)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
+
+// roundTrip reports if inverse maps the destination of mf back to its
+// source.
+func roundTrip(mf, inverse *mappingFunc) bool {
+	for _, m := range []*mappingFunc{mf, inverse} {
+		if !m.StructMapping() || m.transitive || len(m.addedSrcs) > 0 {
+			return false
+		}
+		if m.fn.Signature.TypeParams().Len() > 0 || m.fn.Signature.RecvTypeParams().Len() > 0 {
+			return false
+		}
+	}
+	return types.Identical(unwrapPointer(mf.srcType), unwrapPointer(inverse.dstType)) &&
+		types.Identical(unwrapPointer(mf.dstType), unwrapPointer(inverse.srcType))
+}
+
+// generateRoundTripTest fills the source of mf with random values, maps it
+// with mf and back with inverse, and compares the fields mapped both ways.
+// Fields that are ignored by either mapping, unmatched, mapped one way or
+// converted are not compared and are listed in comments. The test is skipped
+// if no fields are compared.
+func (g *Generator) generateRoundTripTest(name string, mf, inverse *mappingFunc) error {
+	forward, err := g.mapConfiguration(mf)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	backward, err := g.mapConfiguration(inverse)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	srcName := mf.sourceNames()[0]
+	dstName := mf.dstName
+	if dstName == "" {
+		dstName = defaultDstName
+	}
+	backName, rndName := mf.localName("back"), mf.localName("rnd")
+
+	inverses := map[string]mapper.FieldPair{}
+	for _, p := range backward.Pairs {
+		if !p.Value {
+			inverses[p.Source.Path()+"\x00"+p.Destination.Path()] = p
+		}
+	}
+	ignored := map[string]bool{}
+	for _, f := range backward.Ignored {
+		ignored[f.Path()] = true
+	}
+
+	compared, oneWay, lossy, unsupported := []mapper.FieldPair{}, []mapper.Field{}, []mapper.Field{}, []mapper.Field{}
+	seen := map[string]bool{}
+	for _, p := range forward.Pairs {
+		if p.Value {
+			continue
+		}
+		seen[p.Source.Path()] = true
+		q, ok := inverses[p.Destination.Path()+"\x00"+p.Source.Path()]
+		switch {
+		case !ok:
+			if !ignored[p.Source.Path()] {
+				oneWay = append(oneWay, p.Source)
+			}
+		case g.converted(mf, p.Source.Type(), p.Destination.Type()) || g.converted(inverse, q.Source.Type(), q.Destination.Type()):
+			lossy = append(lossy, p.Source)
+		case g.random(rndName, unwrapPointer(p.Source.Type())) == nil:
+			unsupported = append(unsupported, p.Source)
+		default:
+			compared = append(compared, p)
+		}
+	}
+	for _, p := range backward.Pairs {
+		if !seen[p.Destination.Path()] {
+			seen[p.Destination.Path()] = true
+			oneWay = append(oneWay, p.Destination)
+		}
+	}
+
+	body := []Code{}
+	if ignoredFields := append(append([]mapper.Field{}, forward.Ignored...), backward.Ignored...); len(ignoredFields) > 0 {
+		body = append(body, Commentf("ignored %s", quotedPaths(ignoredFields)))
+	}
+	if len(forward.NoMatch) > 0 {
+		body = append(body, Commentf("no match for %s", quotedPaths(forward.NoMatch)))
+	}
+	if len(oneWay) > 0 {
+		body = append(body, Commentf("not mapped both ways %s", quotedPaths(oneWay)))
+	}
+	if len(lossy) > 0 {
+		body = append(body, Commentf("converted %s", quotedPaths(lossy)))
+	}
+	if len(unsupported) > 0 {
+		body = append(body, Commentf("no random values for %s", quotedPaths(unsupported)))
+	}
+
+	var calls []Code
+	if len(compared) > 0 {
+		calls = g.roundTripCalls(mf, inverse, srcName, dstName, backName, rndName, compared)
+	}
+	switch {
+	case len(compared) == 0:
+		body = append(body, Id("t").Dot("Skip").Call(Lit("no fields mapped both ways")))
+	case calls == nil:
+		body = append(body, Id("t").Dot("Skip").Call(Lit(fmt.Sprintf("unable to call %s and %s from a test", mf.name, inverse.name))))
+	default:
+		body = append(body, calls...)
+	}
+
+	g.testFile(mf.fileName).Func().Id(name).Params(Id("t").Op("*").Qual("testing", "T")).Block(body...)
+	return nil
+}
+
+// roundTripCalls returns the statements filling the source with random
+// values from rndName, calling both mappings and comparing the compared
+// fields. It returns nil if the mappings can not be called from a test.
+func (g *Generator) roundTripCalls(mf, inverse *mappingFunc, srcName, dstName, backName, rndName string, compared []mapper.FieldPair) []Code {
+	decls, call := g.testCall(mf, []string{srcName}, dstName, true, "t")
+	if call == nil {
+		return nil
+	}
+
+	// the destination of mf as declared in the test, adapted to the source
+	// of inverse
+	have := mf.dstType
+	want := inverse.srcType
+	if inverse.srcReceiver {
+		want = inverse.fn.Signature.Recv().Type()
+	} else if inverse.srcParam >= 0 {
+		want = inverse.params[inverse.srcParam].Type()
+	}
+	src := Id(dstName)
+	if isPointer(have) && !isPointer(want) {
+		src = Op("*").Id(dstName)
+		if inverse.srcReceiver {
+			src = Parens(src)
+		}
+	} else if !isPointer(have) && isPointer(want) && !inverse.srcReceiver {
+		// methods of addressable values can be called with pointer receivers
+		src = Op("&").Id(dstName)
+	}
+	back := g.testCallSource(inverse, src, nil, backName, true, "t")
+	if back == nil {
+		return nil
+	}
+
+	fill := []Code{
+		Id(rndName).Op(":=").Qual("math/rand", "New").Call(Qual("math/rand", "NewSource").Call(Lit(1))),
+	}
+	allocated := map[string]bool{}
+	filled := map[string]bool{}
+	fillField := func(parents []mapper.Field, name string, ty types.Type) bool {
+		path := name
+		if len(parents) > 0 {
+			path = parents[len(parents)-1].Path() + "." + name
+		}
+		if filled[path] {
+			return true
+		}
+		value := g.random(rndName, unwrapPointer(ty))
+		if value == nil {
+			return false
+		}
+		stmts := g.assignSource(srcName, parents, name, ty, value, allocated)
+		if stmts == nil {
+			return false
+		}
+		filled[path] = true
+		fill = append(fill, stmts...)
+		return true
+	}
+
+	// all fields are filled so that expressions mapping them do not fail on
+	// zero values
+	if st := unwrapStruct(mf.srcType); st != nil {
+		for i := 0; i < st.NumFields(); i++ {
+			f := st.Field(i)
+			if f.Name() == "_" || !f.Exported() && f.Pkg() != g.ssapkg.Pkg {
+				continue
+			}
+			fillField(nil, f.Name(), f.Type())
+		}
+	}
+
+	checks := []Code{}
+	for _, p := range compared {
+		if !fillField(p.Source.Parents(), p.Source.Name(), p.Source.Type()) {
+			continue
+		}
+		got, want := Id(backName), Id(srcName)
+		for _, parent := range p.Source.Parents() {
+			got, want = got.Dot(parent.Name()), want.Dot(parent.Name())
+		}
+		got, want = got.Dot(p.Source.Name()), want.Dot(p.Source.Name())
+		checks = append(checks, If(Op("!").Qual("reflect", "DeepEqual").Call(got.Clone(), want.Clone())).Block(
+			Id("t").Dot("Errorf").Call(Lit(backName+"."+p.Source.Path()+" = %#v, want %#v"), got.Clone(), want.Clone()),
+		))
+	}
+
+	body := append(decls, fill...)
+	body = append(body, call...)
+	body = append(body, back...)
+	return append(body, checks...)
+}
+
+// random returns an expression for a random value of ty using the rand.Rand
+// named rnd, or nil if there is none.
+func (g *Generator) random(rnd string, ty types.Type) *Statement {
+	if !g.nameable(ty) {
+		return nil
+	}
+	var value *Statement
+	switch u := ty.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsString != 0:
+			value = Qual("strconv", "Itoa").Call(Id(rnd).Dot("Int").Call())
+		case info&types.IsBoolean != 0:
+			value = Id(rnd).Dot("Intn").Call(Lit(2)).Op("==").Lit(1)
+		case info&types.IsInteger != 0:
+			value = Id(rnd).Dot("Intn").Call(Lit(100))
+		case info&types.IsFloat != 0:
+			value = Id(rnd).Dot("Float64").Call()
+		default:
+			return nil
+		}
+		if !isDefaultType(ty) {
+			value = g.genType(ty).Call(value)
+		}
+	case *types.Slice:
+		elem := g.random(rnd, u.Elem())
+		if elem == nil {
+			return nil
+		}
+		value = g.genType(ty).Values(elem, elem.Clone())
+	case *types.Map:
+		key := g.random(rnd, u.Key())
+		elem := g.random(rnd, u.Elem())
+		if key == nil || elem == nil {
+			return nil
+		}
+		value = g.genType(ty).Values(Dict{key: elem})
+	}
+	return value
+}
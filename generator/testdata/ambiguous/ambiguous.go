@@ -0,0 +1,17 @@
+package ambiguous
+
+type Child struct {
+	Name string
+}
+
+type ChildView struct {
+	Name *string
+}
+
+type Parent struct {
+	Child Child
+}
+
+type ParentView struct {
+	Child ChildView
+}
@@ -0,0 +1,25 @@
+//go:build typemapper
+
+package ambiguous
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapChild(src Child) ChildView {
+	var dst ChildView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapChildAgain(src Child) ChildView {
+	var dst ChildView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapParent(src Parent) ParentView {
+	var dst ParentView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
@@ -0,0 +1,20 @@
+package ambiguouscontext
+
+type NameLookup map[string]string
+
+type Product struct {
+	SKU  string
+	Name string
+}
+
+func ResolveProduct(names NameLookup, sku string) Product {
+	return Product{SKU: sku, Name: names[sku]}
+}
+
+type OrderLine struct {
+	Product string
+}
+
+type OrderLineView struct {
+	Product Product
+}
@@ -0,0 +1,14 @@
+//go:build typemapper
+
+package ambiguouscontext
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapOrderLine(src OrderLine, names, fallback NameLookup) OrderLineView {
+	var dst OrderLineView
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(ResolveProduct)
+	return dst
+}
@@ -0,0 +1,11 @@
+package ambiguousconverters
+
+import "time"
+
+type Request struct {
+	Timeout time.Duration
+}
+
+type RequestView struct {
+	Timeout string
+}
@@ -0,0 +1,16 @@
+//go:build typemapper
+
+package ambiguousconverters
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+
+	_ "example.com/testdata/convert"
+)
+
+func MapRequestView(src Request) RequestView {
+	var dst RequestView
+	typemapper.CreateMap(src, dst)
+	typemapper.UseConverters("example.com/testdata/convert")
+	return dst
+}
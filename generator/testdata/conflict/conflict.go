@@ -0,0 +1,10 @@
+package conflict
+
+type ServiceNode struct {
+	ServiceName string
+	GetName     string
+}
+
+type Node struct {
+	Name string
+}
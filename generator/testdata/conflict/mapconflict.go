@@ -0,0 +1,14 @@
+//go:build typemapper
+
+package conflict
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapNode(src ServiceNode) Node {
+	var dst Node
+	typemapper.CreateMap(src, dst)
+	typemapper.RecognizePrefixes("Service", "Get")
+	return dst
+}
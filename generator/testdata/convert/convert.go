@@ -0,0 +1,48 @@
+package convert
+
+import (
+	"strconv"
+	"time"
+)
+
+type Temperature float64
+
+func (t Temperature) String() string {
+	return strconv.FormatFloat(float64(t), 'f', 1, 64)
+}
+
+func FormatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func FormatCount(i int) string {
+	return strconv.Itoa(i)
+}
+
+// ParseCount is not a converter, it returns an error.
+func ParseCount(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// TrimSpace is not used as a converter, its types are already assignable.
+func TrimSpace(s string) string {
+	return s
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+type Fahrenheit float64
+
+func Celsius(f Fahrenheit) Temperature {
+	return Temperature((f - 32) / 1.8)
+}
+
+func FormatDuration(d time.Duration) string {
+	return d.String()
+}
+
+func DurationToString(d time.Duration) string {
+	return d.String()
+}
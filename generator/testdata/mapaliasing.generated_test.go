@@ -0,0 +1,41 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapCustomers(t *testing.T) {
+	src := []*Customer{new(Customer), new(Customer)}
+	dst := MapCustomers(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
+func TestMapResponseView(t *testing.T) {
+	src := new(Response)
+	src.Items = []string{"src.Items 1"}
+	src.Meta = map[string]string{"src.Meta 2": "src.Meta 2"}
+	src.Status = "src.Status 3"
+	dst := MapResponseView(src)
+	if !reflect.DeepEqual(dst.Items, []string{"src.Items 1"}) {
+		t.Errorf("dst.Items = %#v, want %#v", dst.Items, []string{"src.Items 1"})
+	}
+	if !reflect.DeepEqual(dst.Meta, map[string]string{"src.Meta 2": "src.Meta 2"}) {
+		t.Errorf("dst.Meta = %#v, want %#v", dst.Meta, map[string]string{"src.Meta 2": "src.Meta 2"})
+	}
+	if dst.Status == nil || *dst.Status != "src.Status 3" {
+		t.Errorf("dst.Status does not point to %#v", "src.Status 3")
+	}
+}
+func TestMapResponseViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ResponseView{}), false, "Items", "Meta", "Owner", "Root", "Status")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
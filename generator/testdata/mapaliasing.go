@@ -0,0 +1,19 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapResponseView(src *Response) ResponseView {
+	var dst ResponseView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapCustomers(src []*Customer) []*Customer {
+	var dst []*Customer
+	typemapper.CreateMap(src, dst)
+	return dst
+}
@@ -0,0 +1,25 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapChild(src ChildSourceStruct) ChildDestStruct {
+	dst := ChildDestStruct{}
+	dst.Name = &src.Name
+	return dst
+}
+func MapParent(src ParentSourceStruct) ParentDestStruct {
+	dst := ParentDestStruct{}
+	dst.Child = MapChild(src.Child)
+	for _, x := range src.Children {
+		dst.Children = append(dst.Children, MapChild(x))
+	}
+	return dst
+}
+func MapParentDisableAutoMapWith(src ParentSourceStruct) ParentDestStruct {
+	dst := ParentDestStruct{}
+	dst.Child = MapChild(src.Child)
+	// ignored "Children"
+	return dst
+}
@@ -0,0 +1,46 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapChild(t *testing.T) {
+	var src ChildSourceStruct
+	src.Name = "src.Name 1"
+	dst := MapChild(src)
+	if dst.Name == nil || *dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name does not point to %#v", "src.Name 1")
+	}
+}
+func TestMapChildUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ChildDestStruct{}), false, "Name")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapParent(t *testing.T) {
+	var src ParentSourceStruct
+	_ = MapParent(src)
+}
+func TestMapParentUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ParentDestStruct{}), false, "Child", "Children")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapParentDisableAutoMapWith(t *testing.T) {
+	var src ParentSourceStruct
+	_ = MapParentDisableAutoMapWith(src)
+}
+func TestMapParentDisableAutoMapWithUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ParentDestStruct{}), false, "Child", "Children")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
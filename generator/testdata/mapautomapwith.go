@@ -0,0 +1,28 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapChild(src ChildSourceStruct) ChildDestStruct {
+	var dst ChildDestStruct
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapParent(src ParentSourceStruct) ParentDestStruct {
+	var dst ParentDestStruct
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapParentDisableAutoMapWith(src ParentSourceStruct) ParentDestStruct {
+	var dst ParentDestStruct
+	typemapper.CreateMap(src, dst)
+	typemapper.DisableAutoMapWith()
+	typemapper.MapWith(MapChild)
+	typemapper.IgnoreFields(dst.Children)
+	return dst
+}
@@ -0,0 +1,35 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapOrder(t *testing.T) {
+	var src Order
+	src.Customer = "src.Customer 1"
+	_ = MapOrder(context.Background(), src, nil)
+}
+func TestMapOrderUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(OrderView{}), false, "Customer", "Lines")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapOrderLine(t *testing.T) {
+	var src OrderLine
+	src.Product = "src.Product 1"
+	_ = MapOrderLine(context.Background(), src, nil)
+}
+func TestMapOrderLineUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(OrderLineView{}), false, "Product")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,23 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	"context"
+
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapOrderLine(ctx context.Context, src OrderLine, names NameLookup) OrderLineView {
+	var dst OrderLineView
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(ResolveProduct)
+	return dst
+}
+
+func MapOrder(ctx context.Context, src Order, names NameLookup) OrderView {
+	var dst OrderView
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(ResolveCustomer)
+	return dst
+}
@@ -0,0 +1,32 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	convert "example.com/testdata/convert"
+	"strconv"
+)
+
+func MapConvertStructMapWith(src ConvertSourceStruct) ConvertDestStruct {
+	dst := ConvertDestStruct{}
+	dst.Created = convert.FormatTime(src.Created)
+	dst.Count = strconv.Itoa(src.Count)
+	dst.Temperature = src.Temperature.String()
+	dst.Name = src.Name
+	return dst
+}
+func MapConvertStructUseConverters(src ConvertSourceStruct) ConvertDestStruct {
+	dst := ConvertDestStruct{}
+	dst.Created = convert.FormatTime(src.Created)
+	dst.Count = convert.FormatCount(src.Count)
+	dst.Temperature = src.Temperature.String()
+	dst.Name = src.Name
+	return dst
+}
+func MapForecastView(src Forecast) ForecastView {
+	dst := ForecastView{}
+	dst.High = convert.Celsius(src.High)
+	return dst
+}
@@ -0,0 +1,55 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapConvertStructMapWith(t *testing.T) {
+	var src ConvertSourceStruct
+	src.Count = 1
+	src.Temperature = 2.5
+	src.Name = "src.Name 3"
+	dst := MapConvertStructMapWith(src)
+	if dst.Name != "src.Name 3" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 3")
+	}
+}
+func TestMapConvertStructMapWithUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ConvertDestStruct{}), false, "Created", "Count", "Temperature", "Name")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapConvertStructUseConverters(t *testing.T) {
+	var src ConvertSourceStruct
+	src.Count = 1
+	src.Temperature = 2.5
+	src.Name = "src.Name 3"
+	dst := MapConvertStructUseConverters(src)
+	if dst.Name != "src.Name 3" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 3")
+	}
+}
+func TestMapConvertStructUseConvertersUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ConvertDestStruct{}), false, "Created", "Count", "Temperature", "Name")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapForecastView(t *testing.T) {
+	var src Forecast
+	src.High = 1.5
+	_ = MapForecastView(src)
+}
+func TestMapForecastViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ForecastView{}), false, "High")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,32 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	"strconv"
+
+	typemapper "github.com/paultyng/go-typemapper"
+
+	"example.com/testdata/convert"
+)
+
+func MapConvertStructMapWith(src ConvertSourceStruct) ConvertDestStruct {
+	var dst ConvertDestStruct
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(convert.FormatTime, strconv.Itoa, src.Temperature.String)
+	return dst
+}
+
+func MapConvertStructUseConverters(src ConvertSourceStruct) ConvertDestStruct {
+	var dst ConvertDestStruct
+	typemapper.CreateMap(src, dst)
+	typemapper.UseConverters("example.com/testdata/convert")
+	return dst
+}
+
+func MapForecastView(src Forecast) ForecastView {
+	var dst ForecastView
+	typemapper.CreateMap(src, dst)
+	typemapper.UseConverters("example.com/testdata/convert")
+	return dst
+}
@@ -0,0 +1,211 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func cloneTreeDeepCopySliceString(src []string) []string {
+	if src == nil {
+		return nil
+	}
+	dst := make([]string, len(src))
+	copy(dst, src)
+	return dst
+}
+func cloneTreeDeepCopyLabels(src Labels) Labels {
+	if src == nil {
+		return nil
+	}
+	dst := make(Labels, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+func cloneTreeDeepCopySlicePtrTreeNode(src []*TreeNode, seen map[any]any) []*TreeNode {
+	if src == nil {
+		return nil
+	}
+	dst := make([]*TreeNode, len(src))
+	for i, x := range src {
+		dst[i] = cloneTreeDeepCopyPtrTreeNode(x, seen)
+	}
+	return dst
+}
+func cloneTreeDeepCopySliceInt(src []int) []int {
+	if src == nil {
+		return nil
+	}
+	dst := make([]int, len(src))
+	copy(dst, src)
+	return dst
+}
+func cloneTreeDeepCopyArray2SliceInt(src [2][]int) [2][]int {
+	dst := src
+	for i, x := range src {
+		dst[i] = cloneTreeDeepCopySliceInt(x)
+	}
+	return dst
+}
+func cloneTreeDeepCopyTreeNode(src TreeNode, seen map[any]any) TreeNode {
+	dst := src
+	dst.Tags = cloneTreeDeepCopySliceString(src.Tags)
+	dst.Labels = cloneTreeDeepCopyLabels(src.Labels)
+	dst.Parent = cloneTreeDeepCopyPtrTreeNode(src.Parent, seen)
+	dst.Children = cloneTreeDeepCopySlicePtrTreeNode(src.Children, seen)
+	dst.Weights = cloneTreeDeepCopyArray2SliceInt(src.Weights)
+	dst.Secret = CopySecret(src.Secret)
+	return dst
+}
+func cloneTreeDeepCopyPtrTreeNode(src *TreeNode, seen map[any]any) *TreeNode {
+	if src == nil {
+		return nil
+	}
+	if dst, ok := seen[src]; ok {
+		return dst.(*TreeNode)
+	}
+	dst := new(TreeNode)
+	seen[src] = dst
+	*dst = cloneTreeDeepCopyTreeNode(*src, seen)
+	return dst
+}
+func cloneTreeDeepCopyMapStringPtrTreeNode(src map[string]*TreeNode, seen map[any]any) map[string]*TreeNode {
+	if src == nil {
+		return nil
+	}
+	dst := make(map[string]*TreeNode, len(src))
+	for k, v := range src {
+		dst[k] = cloneTreeDeepCopyPtrTreeNode(v, seen)
+	}
+	return dst
+}
+func cloneTreeDeepCopyPtrString(src *string, seen map[any]any) *string {
+	if src == nil {
+		return nil
+	}
+	if dst, ok := seen[src]; ok {
+		return dst.(*string)
+	}
+	dst := new(string)
+	seen[src] = dst
+	*dst = *src
+	return dst
+}
+func cloneTreeDeepCopyStructNamePtrString(src struct {
+	Name *string "json:\"name\""
+}, seen map[any]any) struct {
+	Name *string "json:\"name\""
+} {
+	dst := src
+	dst.Name = cloneTreeDeepCopyPtrString(src.Name, seen)
+	return dst
+}
+func CloneTree(src Tree) TreeCopy {
+	dst := TreeCopy{}
+	seen := map[any]any{}
+	dst.Root = cloneTreeDeepCopyPtrTreeNode(src.Root, seen)
+	dst.Index = cloneTreeDeepCopyMapStringPtrTreeNode(src.Index, seen)
+	dst.Secret = CopySecret(src.Secret)
+	dst.Name = *src.Name
+	dst.Owner = cloneTreeDeepCopyStructNamePtrString(src.Owner, seen)
+	return dst
+}
+func deepCopySliceString(src []string) []string {
+	if src == nil {
+		return nil
+	}
+	dst := make([]string, len(src))
+	copy(dst, src)
+	return dst
+}
+func deepCopyLabels(src Labels) Labels {
+	if src == nil {
+		return nil
+	}
+	dst := make(Labels, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+func deepCopySlicePtrTreeNode(src []*TreeNode, seen map[any]any) []*TreeNode {
+	if src == nil {
+		return nil
+	}
+	dst := make([]*TreeNode, len(src))
+	for i, x := range src {
+		dst[i] = deepCopyPtrTreeNode(x, seen)
+	}
+	return dst
+}
+func deepCopySliceInt(src []int) []int {
+	if src == nil {
+		return nil
+	}
+	dst := make([]int, len(src))
+	copy(dst, src)
+	return dst
+}
+func deepCopyArray2SliceInt(src [2][]int) [2][]int {
+	dst := src
+	for i, x := range src {
+		dst[i] = deepCopySliceInt(x)
+	}
+	return dst
+}
+func deepCopySliceByte(src []byte) []byte {
+	if src == nil {
+		return nil
+	}
+	dst := make([]byte, len(src))
+	copy(dst, src)
+	return dst
+}
+func deepCopySecret(src Secret) Secret {
+	dst := src
+	dst.Key = deepCopySliceByte(src.Key)
+	return dst
+}
+func deepCopyTreeNode(src TreeNode, seen map[any]any) TreeNode {
+	dst := src
+	dst.Tags = deepCopySliceString(src.Tags)
+	dst.Labels = deepCopyLabels(src.Labels)
+	dst.Parent = deepCopyPtrTreeNode(src.Parent, seen)
+	dst.Children = deepCopySlicePtrTreeNode(src.Children, seen)
+	dst.Weights = deepCopyArray2SliceInt(src.Weights)
+	dst.Secret = deepCopySecret(src.Secret)
+	return dst
+}
+func deepCopyPtrTreeNode(src *TreeNode, seen map[any]any) *TreeNode {
+	if src == nil {
+		return nil
+	}
+	if dst, ok := seen[src]; ok {
+		return dst.(*TreeNode)
+	}
+	dst := new(TreeNode)
+	seen[src] = dst
+	*dst = deepCopyTreeNode(*src, seen)
+	return dst
+}
+func CloneTreeNode(src *TreeNode) *TreeNode {
+	dst := new(TreeNode)
+	seen := map[any]any{src: dst}
+	dst.Name = src.Name
+	dst.Tags = deepCopySliceString(src.Tags)
+	dst.Labels = deepCopyLabels(src.Labels)
+	dst.Parent = deepCopyPtrTreeNode(src.Parent, seen)
+	dst.Children = deepCopySlicePtrTreeNode(src.Children, seen)
+	dst.Created = src.Created
+	dst.Weights = deepCopyArray2SliceInt(src.Weights)
+	dst.Secret = deepCopySecret(src.Secret)
+	return dst
+}
+func CloneTreeNodes(src []*TreeNode) []*TreeNode {
+	var dst []*TreeNode
+	seen := map[any]any{}
+	for _, x := range src {
+		dst = append(dst, deepCopyPtrTreeNode(x, seen))
+	}
+	return dst
+}
@@ -0,0 +1,75 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCloneTree(t *testing.T) {
+	var src Tree
+	srcName := "src.Name 1"
+	src.Name = &srcName
+	dst := CloneTree(src)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+}
+
+// staleFields returns the fields of ty that are not known, prefixed with name.
+func staleFields(name string, ty reflect.Type, exported bool, known ...string) []string {
+	seen := map[string]bool{}
+	for _, k := range known {
+		seen[k] = true
+	}
+	fields := []string{}
+	for i := 0; i < ty.NumField(); i++ {
+		f := ty.Field(i)
+		if exported && !f.IsExported() {
+			continue
+		}
+		if !seen[f.Name] {
+			fields = append(fields, name+"."+f.Name)
+		}
+	}
+	return fields
+}
+func TestCloneTreeUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(TreeCopy{}), false, "Root", "Index", "Secret", "Name", "Owner")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestCloneTreeNode(t *testing.T) {
+	src := new(TreeNode)
+	src.Name = "src.Name 1"
+	src.Tags = []string{"src.Tags 2"}
+	src.Labels = Labels{"src.Labels 3": "src.Labels 3"}
+	dst := CloneTreeNode(src)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"src.Tags 2"}) {
+		t.Errorf("dst.Tags = %#v, want %#v", dst.Tags, []string{"src.Tags 2"})
+	}
+	if !reflect.DeepEqual(dst.Labels, Labels{"src.Labels 3": "src.Labels 3"}) {
+		t.Errorf("dst.Labels = %#v, want %#v", dst.Labels, Labels{"src.Labels 3": "src.Labels 3"})
+	}
+}
+func TestCloneTreeNodeUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(TreeNode{}), false, "Name", "Tags", "Labels", "Parent", "Children", "Created", "Weights", "Secret")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestCloneTreeNodes(t *testing.T) {
+	src := []*TreeNode{new(TreeNode), new(TreeNode)}
+	dst := CloneTreeNodes(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+}
@@ -0,0 +1,29 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func CloneTreeNode(src *TreeNode) *TreeNode {
+	dst := &TreeNode{}
+	typemapper.CreateMap(src, dst)
+	typemapper.DeepCopy()
+	return dst
+}
+
+func CloneTree(src Tree) TreeCopy {
+	var dst TreeCopy
+	typemapper.CreateMap(src, dst)
+	typemapper.DeepCopy()
+	typemapper.MapWith(CopySecret)
+	return dst
+}
+
+func CloneTreeNodes(src []*TreeNode) []*TreeNode {
+	var dst []*TreeNode
+	typemapper.CreateMap(src, dst)
+	typemapper.DeepCopy()
+	return dst
+}
@@ -0,0 +1,36 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapDocumentRecord(src *DocumentView, dst *DocumentRecord) {
+	if dst == nil {
+		return
+	}
+	if src.EntityView != nil {
+		dst.Entity.ID = src.EntityView.ID
+	}
+	dst.Entity.Version = src.Version
+	if dst.Audit == nil {
+		dst.Audit = new(Audit)
+	}
+	dst.Audit.CreatedBy = src.CreatedBy
+	dst.Audit.Note = src.Note
+	dst.Title = src.Title
+	return
+}
+func MapDocumentView(src Document) DocumentView {
+	dst := DocumentView{}
+	if dst.EntityView == nil {
+		dst.EntityView = new(EntityView)
+	}
+	dst.EntityView.ID = src.Entity.ID
+	dst.Title = src.Title
+	dst.Version = src.Version
+	if src.Audit != nil {
+		dst.CreatedBy = src.Audit.CreatedBy
+	}
+	// ambiguous selector "Note"
+	return dst
+}
@@ -0,0 +1,53 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapDocumentRecord(t *testing.T) {
+	src := new(DocumentView)
+	src.Title = "src.Title 1"
+	src.Version = 2
+	src.CreatedBy = "src.CreatedBy 3"
+	src.Note = "src.Note 4"
+	src.EntityView = new(EntityView)
+	src.EntityView.ID = "src.EntityView.ID 5"
+	dst := new(DocumentRecord)
+	MapDocumentRecord(src, dst)
+	if dst.Entity.ID != "src.EntityView.ID 5" {
+		t.Errorf("dst.Entity.ID = %#v, want %#v", dst.Entity.ID, "src.EntityView.ID 5")
+	}
+	if dst.Entity.Version != 2 {
+		t.Errorf("dst.Entity.Version = %#v, want %#v", dst.Entity.Version, 2)
+	}
+	if dst.Audit.CreatedBy != "src.CreatedBy 3" {
+		t.Errorf("dst.Audit.CreatedBy = %#v, want %#v", dst.Audit.CreatedBy, "src.CreatedBy 3")
+	}
+	if dst.Audit.Note != "src.Note 4" {
+		t.Errorf("dst.Audit.Note = %#v, want %#v", dst.Audit.Note, "src.Note 4")
+	}
+	if dst.Title != "src.Title 1" {
+		t.Errorf("dst.Title = %#v, want %#v", dst.Title, "src.Title 1")
+	}
+}
+func TestMapDocumentRecordUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DocumentRecord{}), false, "Entity", "Audit", "Title")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapDocumentView(t *testing.T) {
+	t.Fatal("ambiguous selectors: [Note]")
+}
+func TestMapDocumentViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DocumentView{}), false, "EntityView", "Title", "Version", "CreatedBy", "Note")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
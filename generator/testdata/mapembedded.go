@@ -0,0 +1,17 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapDocumentView(src Document) DocumentView {
+	var dst DocumentView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapDocumentRecord(src *DocumentView, dst *DocumentRecord) {
+	typemapper.CreateMap(src, dst)
+}
@@ -0,0 +1,48 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"fmt"
+	"strings"
+)
+
+func MapMeasurementView(src Measurement) MeasurementView {
+	dst := MeasurementView{}
+	dst.Fahrenheit = (src.Celsius * 1.8) + 32.0
+	dst.Scaled = src.Celsius * (-0.5)
+	return dst
+}
+func MapPersonDomain(v Person) PersonSummary {
+	dst := PersonSummary{}
+	{
+		v1 := v.Email[strings.Index(v.Email, "@")+1:]
+		dst.Domain = &v1
+	}
+	dst.Email = v.Email
+	// ignored "Name", "Initials", "ItemCount", "FirstTag", "Label"
+	return dst
+}
+func MapPersonSummary(src Person) PersonSummary {
+	dst := PersonSummary{}
+	dst.Name = src.FullName()
+	dst.Initials = src.First[:1] + src.Last[:1]
+	dst.ItemCount = len(src.Items)
+	{
+		v := src.Email[strings.Index(src.Email, "@")+1:]
+		dst.Domain = &v
+	}
+	dst.Email = strings.ToLower(src.Email)
+	dst.FirstTag = src.Tags["first"]
+	dst.Label = fmt.Sprintf("%s (%d)", src.Last, len(src.Items))
+	return dst
+}
+func MapPersonSummaryPtr(src *Person) PersonSummary {
+	dst := PersonSummary{}
+	dst.Name = src.FullName()
+	dst.Email = src.Email
+	// ignored "Initials", "ItemCount", "Domain", "FirstTag", "Label"
+	return dst
+}
@@ -0,0 +1,74 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapMeasurementView(t *testing.T) {
+	var src Measurement
+	src.Celsius = 1.5
+	_ = MapMeasurementView(src)
+}
+func TestMapMeasurementViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(MeasurementView{}), false, "Fahrenheit", "Scaled")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapPersonDomain(t *testing.T) {
+	var v Person
+	v.First = "v.First 1"
+	v.Last = "v.Last 2"
+	v.Items = []string{"v.Items 3"}
+	v.Email = "v.Email 4"
+	v.Tags = map[string]string{"v.Tags 5": "v.Tags 5"}
+	dst := MapPersonDomain(v)
+	if dst.Email != "v.Email 4" {
+		t.Errorf("dst.Email = %#v, want %#v", dst.Email, "v.Email 4")
+	}
+}
+func TestMapPersonDomainUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(PersonSummary{}), false, "Name", "Initials", "ItemCount", "Domain", "Email", "FirstTag", "Label")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapPersonSummary(t *testing.T) {
+	var src Person
+	src.First = "src.First 1"
+	src.Last = "src.Last 2"
+	src.Items = []string{"src.Items 3"}
+	src.Email = "src.Email 4"
+	src.Tags = map[string]string{"src.Tags 5": "src.Tags 5"}
+	_ = MapPersonSummary(src)
+}
+func TestMapPersonSummaryUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(PersonSummary{}), false, "Name", "Initials", "ItemCount", "Domain", "Email", "FirstTag", "Label")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapPersonSummaryPtr(t *testing.T) {
+	src := new(Person)
+	src.First = "src.First 1"
+	src.Last = "src.Last 2"
+	src.Items = []string{"src.Items 3"}
+	src.Email = "src.Email 4"
+	src.Tags = map[string]string{"src.Tags 5": "src.Tags 5"}
+	dst := MapPersonSummaryPtr(src)
+	if dst.Email != "src.Email 4" {
+		t.Errorf("dst.Email = %#v, want %#v", dst.Email, "src.Email 4")
+	}
+}
+func TestMapPersonSummaryPtrUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(PersonSummary{}), false, "Name", "Initials", "ItemCount", "Domain", "Email", "FirstTag", "Label")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,47 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	"fmt"
+	"strings"
+
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapPersonSummary(src Person) PersonSummary {
+	var dst PersonSummary
+	typemapper.CreateMap(src, dst)
+	typemapper.MapField(src.FullName(), dst.Name)
+	typemapper.MapField(src.First[:1]+src.Last[:1], dst.Initials)
+	typemapper.MapField(len(src.Items), dst.ItemCount)
+	typemapper.MapField(src.Email[strings.Index(src.Email, "@")+1:], dst.Domain)
+	typemapper.MapField(strings.ToLower(src.Email), dst.Email)
+	typemapper.MapField(src.Tags["first"], dst.FirstTag)
+	typemapper.MapField(fmt.Sprintf("%s (%d)", src.Last, len(src.Items)), dst.Label)
+	return dst
+}
+
+func MapPersonSummaryPtr(src *Person) PersonSummary {
+	var dst PersonSummary
+	typemapper.CreateMap(src, dst)
+	typemapper.MapField(src.FullName(), dst.Name)
+	typemapper.IgnoreFields(dst.Initials, dst.ItemCount, dst.Domain, dst.FirstTag, dst.Label)
+	return dst
+}
+
+func MapMeasurementView(src Measurement) MeasurementView {
+	var dst MeasurementView
+	typemapper.CreateMap(src, dst)
+	typemapper.MapField(src.Celsius*1.8+32, dst.Fahrenheit)
+	typemapper.MapField(src.Celsius*-0.5, dst.Scaled)
+	return dst
+}
+
+func MapPersonDomain(v Person) PersonSummary {
+	var dst PersonSummary
+	typemapper.CreateMap(v, dst)
+	typemapper.MapField(v.Email[strings.Index(v.Email, "@")+1:], dst.Domain)
+	typemapper.IgnoreFields(dst.Name, dst.Initials, dst.ItemCount, dst.FirstTag, dst.Label)
+	return dst
+}
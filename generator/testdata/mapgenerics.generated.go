@@ -0,0 +1,41 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapOptionalStruct(src OptionalSourceStruct) OptionalDestStruct {
+	dst := OptionalDestStruct{}
+	dst.StringMatch = src.StringMatch
+	dst.PointerMatch = &src.PointerMatch
+	return dst
+}
+func MapPage[T any, U any](src Page[T], mapItem func(T) U) Page[U] {
+	dst := Page[U]{}
+	for _, x := range src.Items {
+		dst.Items = append(dst.Items, mapItem(x))
+	}
+	dst.Total = src.Total
+	return dst
+}
+func MapPageNumbers[T interface {
+	~int | ~int64
+}, U ~int | ~string](src Page[T], mapItem func(T) U) Page[U] {
+	dst := Page[U]{}
+	for _, x := range src.Items {
+		dst.Items = append(dst.Items, mapItem(x))
+	}
+	dst.Total = src.Total
+	return dst
+}
+func MapPagePtr[T any, U any](src *Page[T], dst *Page[U], mapItem func(T) U) error {
+	if dst == nil {
+		return nil
+	}
+	dst.Items = dst.Items[:0]
+	for _, x := range src.Items {
+		dst.Items = append(dst.Items, mapItem(x))
+	}
+	dst.Total = src.Total
+	return nil
+}
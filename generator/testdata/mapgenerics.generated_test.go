@@ -0,0 +1,25 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapOptionalStruct(t *testing.T) {
+	var src OptionalSourceStruct
+	_ = MapOptionalStruct(src)
+}
+func TestMapOptionalStructUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(OptionalDestStruct{}), false, "StringMatch", "PointerMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapPage(t *testing.T)        {}
+func TestMapPageNumbers(t *testing.T) {}
+func TestMapPagePtr(t *testing.T)     {}
@@ -0,0 +1,33 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapOptionalStruct(src OptionalSourceStruct) OptionalDestStruct {
+	var dst OptionalDestStruct
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapPage[T, U any](src Page[T], mapItem func(T) U) Page[U] {
+	var dst Page[U]
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(mapItem)
+	return dst
+}
+
+func MapPagePtr[T, U any](src *Page[T], dst *Page[U], mapItem func(T) U) error {
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(mapItem)
+	return nil
+}
+
+func MapPageNumbers[T interface{ ~int | ~int64 }, U ~int | ~string](src Page[T], mapItem func(T) U) Page[U] {
+	var dst Page[U]
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(mapItem)
+	return dst
+}
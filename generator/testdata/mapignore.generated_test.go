@@ -0,0 +1,33 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapCounterState(t *testing.T) {
+	src := new(CounterMessage)
+	src.Name = "src.Name 1"
+	src.Count = 2
+	src.XXX_unrecognized = []byte{3}
+	src.XXX_sizecache = 4
+	dst := new(CounterState)
+	MapCounterState(src, dst)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.Count != 2 {
+		t.Errorf("dst.Count = %#v, want %#v", dst.Count, 2)
+	}
+}
+func TestMapCounterStateUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(CounterState{}), false, "Name", "Count", "InternalVersion", "Lock", "XXX_unrecognized", "XXX_sizecache")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
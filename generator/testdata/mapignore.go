@@ -0,0 +1,16 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	"sync"
+
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapCounterState(src *CounterMessage, dst *CounterState) {
+	typemapper.CreateMap(src, dst)
+	typemapper.IgnoreFieldsMatching("XXX_*")
+	typemapper.IgnoreFieldsMatchingRegexp("^Internal[A-Z]")
+	typemapper.IgnoreFieldsOfType(sync.Mutex{})
+}
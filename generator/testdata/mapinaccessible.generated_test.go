@@ -0,0 +1,33 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	message "example.com/testdata/message"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapMessage(t *testing.T) {
+	var src MessageDraft
+	src.Name = "src.Name 1"
+	src.Body = "src.Body 2"
+	src.sizeCache = 3
+	dst := new(message.Message)
+	MapMessage(src, dst)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.Body != "src.Body 2" {
+		t.Errorf("dst.Body = %#v, want %#v", dst.Body, "src.Body 2")
+	}
+}
+func TestMapMessageUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(message.Message{}), true, "state", "sizeCache", "unknownFields", "Name", "Body")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
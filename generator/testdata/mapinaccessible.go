@@ -0,0 +1,13 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+
+	"example.com/testdata/message"
+)
+
+func MapMessage(src MessageDraft, dst *message.Message) {
+	typemapper.CreateMap(src, dst)
+}
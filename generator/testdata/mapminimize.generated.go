@@ -0,0 +1,26 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapParentMinimizeAllocs(src ParentSourceStruct) *ParentDestStruct {
+	dst := &ParentDestStruct{Child: MapChild(src.Child)}
+	if src.Children != nil {
+		dst.Children = make([]ChildDestStruct, 0, len(src.Children))
+	}
+	for _, x := range src.Children {
+		dst.Children = append(dst.Children, MapChild(x))
+	}
+	return dst
+}
+func MapSliceMinimizeAllocs(src []string) []stringAlias {
+	var dst []stringAlias
+	if src != nil {
+		dst = make([]stringAlias, 0, len(src))
+	}
+	for _, x := range src {
+		dst = append(dst, stringAlias(x))
+	}
+	return dst
+}
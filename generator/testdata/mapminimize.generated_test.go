@@ -0,0 +1,35 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapParentMinimizeAllocs(t *testing.T) {
+	var src ParentSourceStruct
+	_ = MapParentMinimizeAllocs(src)
+}
+func TestMapParentMinimizeAllocsUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ParentDestStruct{}), false, "Child", "Children")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapSliceMinimizeAllocs(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst := MapSliceMinimizeAllocs(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
@@ -0,0 +1,21 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapParentMinimizeAllocs(src ParentSourceStruct) *ParentDestStruct {
+	var dst *ParentDestStruct
+	typemapper.CreateMap(src, dst)
+	typemapper.MinimizeAllocs()
+	return dst
+}
+
+func MapSliceMinimizeAllocs(src []string) []stringAlias {
+	var dst []stringAlias
+	typemapper.CreateMap(src, dst)
+	typemapper.MinimizeAllocs()
+	return dst
+}
@@ -0,0 +1,21 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapReadingViewMismatch(t *testing.T) {
+	t.Fatal("type mismatch for \"Value\": src.Value is string and dst.Value is float64, convert with typemapper.MapWith(func(string) float64)")
+}
+func TestMapReadingViewMismatchUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ReadingView{}), false, "Sensor", "Value")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,13 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapReadingViewMismatch(src Reading) ReadingView {
+	var dst ReadingView
+	typemapper.CreateMap(src, dst)
+	return dst
+}
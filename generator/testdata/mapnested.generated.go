@@ -0,0 +1,20 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapContactView(src Contact) ContactView {
+	dst := ContactView{}
+	dst.Name = src.Name
+	if src.Address != nil {
+		dst.City = src.Address.City
+	}
+	if dst.Meta == nil {
+		dst.Meta = new(Meta)
+	}
+	dst.Meta.CreatedBy = src.Author
+	dst.Meta.UpdatedBy = src.Meta.UpdatedBy
+	// ignored "Meta.Revision"
+	return dst
+}
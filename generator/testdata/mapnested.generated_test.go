@@ -0,0 +1,39 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapContactView(t *testing.T) {
+	var src Contact
+	src.Name = "src.Name 1"
+	src.Author = "src.Author 2"
+	src.Address = new(Address)
+	src.Address.City = "src.Address.City 3"
+	src.Meta.UpdatedBy = "src.Meta.UpdatedBy 4"
+	dst := MapContactView(src)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.City != "src.Address.City 3" {
+		t.Errorf("dst.City = %#v, want %#v", dst.City, "src.Address.City 3")
+	}
+	if dst.Meta.CreatedBy != "src.Author 2" {
+		t.Errorf("dst.Meta.CreatedBy = %#v, want %#v", dst.Meta.CreatedBy, "src.Author 2")
+	}
+	if dst.Meta.UpdatedBy != "src.Meta.UpdatedBy 4" {
+		t.Errorf("dst.Meta.UpdatedBy = %#v, want %#v", dst.Meta.UpdatedBy, "src.Meta.UpdatedBy 4")
+	}
+}
+func TestMapContactViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ContactView{}), false, "Name", "City", "Meta")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,16 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapContactView(src Contact) ContactView {
+	var dst ContactView
+	typemapper.CreateMap(src, dst)
+	typemapper.MapField(src.Address.City, dst.City)
+	typemapper.MapField(src.Author, dst.Meta.CreatedBy)
+	typemapper.IgnoreFields(dst.Meta.Revision)
+	return dst
+}
@@ -0,0 +1,27 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapContactCity(src ContactView) Contact {
+	dst := Contact{}
+	dst.Name = src.Name
+	if dst.Address == nil {
+		dst.Address = new(Address)
+	}
+	dst.Address.City = src.City
+	// ignored "Author", "Address.Street", "Meta"
+	return dst
+}
+func MapDocumentTitle(src DocumentView, dst *Document) {
+	if dst == nil {
+		return
+	}
+	if src.EntityView != nil {
+		dst.Entity.ID = src.EntityView.ID
+	}
+	dst.Title = src.Title
+	// ignored "Audit", "Tagged", "Version"
+	return
+}
@@ -0,0 +1,92 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMapContactCity(t *testing.T) {
+	var src ContactView
+	src.Name = "src.Name 1"
+	src.City = "src.City 2"
+	dst := MapContactCity(src)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.Address.City != "src.City 2" {
+		t.Errorf("dst.Address.City = %#v, want %#v", dst.Address.City, "src.City 2")
+	}
+}
+func TestMapContactCityUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(Contact{}), false, "Name", "Author", "Address", "Meta")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapDocumentTitle(t *testing.T) {
+	var src DocumentView
+	src.Title = "src.Title 1"
+	src.Version = 2
+	src.CreatedBy = "src.CreatedBy 3"
+	src.Note = "src.Note 4"
+	src.EntityView = new(EntityView)
+	src.EntityView.ID = "src.EntityView.ID 5"
+	dst := new(Document)
+	MapDocumentTitle(src, dst)
+	if dst.Entity.ID != "src.EntityView.ID 5" {
+		t.Errorf("dst.Entity.ID = %#v, want %#v", dst.Entity.ID, "src.EntityView.ID 5")
+	}
+	if dst.Title != "src.Title 1" {
+		t.Errorf("dst.Title = %#v, want %#v", dst.Title, "src.Title 1")
+	}
+}
+func TestMapDocumentTitleUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(Document{}), false, "Entity", "Audit", "Tagged", "Title", "Version")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapContactCityRoundTrip(t *testing.T) {
+	// ignored "Author", "Address.Street", "Meta", "Meta.Revision"
+	// not mapped both ways "Meta.CreatedBy", "Meta.UpdatedBy"
+	var src ContactView
+	rnd := rand.New(rand.NewSource(1))
+	src.Name = strconv.Itoa(rnd.Int())
+	src.City = strconv.Itoa(rnd.Int())
+	dst := MapContactCity(src)
+	back := MapContactView(dst)
+	if !reflect.DeepEqual(back.Name, src.Name) {
+		t.Errorf("back.Name = %#v, want %#v", back.Name, src.Name)
+	}
+	if !reflect.DeepEqual(back.City, src.City) {
+		t.Errorf("back.City = %#v, want %#v", back.City, src.City)
+	}
+}
+func TestMapDocumentTitleRoundTrip(t *testing.T) {
+	// ignored "Audit", "Tagged", "Version"
+	// not mapped both ways "Version", "CreatedBy"
+	var src DocumentView
+	rnd := rand.New(rand.NewSource(1))
+	src.Title = strconv.Itoa(rnd.Int())
+	src.Version = rnd.Intn(100)
+	src.CreatedBy = strconv.Itoa(rnd.Int())
+	src.Note = strconv.Itoa(rnd.Int())
+	src.EntityView = new(EntityView)
+	src.EntityView.ID = strconv.Itoa(rnd.Int())
+	dst := new(Document)
+	MapDocumentTitle(src, dst)
+	back := MapDocumentView(*dst)
+	if !reflect.DeepEqual(back.EntityView.ID, src.EntityView.ID) {
+		t.Errorf("back.EntityView.ID = %#v, want %#v", back.EntityView.ID, src.EntityView.ID)
+	}
+	if !reflect.DeepEqual(back.Title, src.Title) {
+		t.Errorf("back.Title = %#v, want %#v", back.Title, src.Title)
+	}
+}
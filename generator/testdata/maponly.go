@@ -0,0 +1,19 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapDocumentTitle(src DocumentView, dst *Document) {
+	typemapper.CreateMap(src, dst)
+	typemapper.OnlyFields(dst.Title, dst.ID)
+}
+
+func MapContactCity(src ContactView) Contact {
+	var dst Contact
+	typemapper.CreateMap(src, dst)
+	typemapper.OnlyFields(dst.Name, dst.Address.City)
+	return dst
+}
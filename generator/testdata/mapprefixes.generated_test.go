@@ -0,0 +1,27 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapServiceView(t *testing.T) {
+	var src ServiceRecord
+	src.ServiceName = "src.ServiceName 1"
+	src.Name = "src.Name 2"
+	dst := MapServiceView(src)
+	if dst.Name != "src.Name 2" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 2")
+	}
+}
+func TestMapServiceViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ServiceView{}), false, "Name")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,14 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapServiceView(src ServiceRecord) ServiceView {
+	var dst ServiceView
+	typemapper.CreateMap(src, dst)
+	typemapper.RecognizePrefixes("Service")
+	return dst
+}
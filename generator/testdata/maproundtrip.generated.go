@@ -0,0 +1,48 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapProfile(src ProfileDTO, dst *Profile) {
+	if dst == nil {
+		return
+	}
+	dst.Name = src.Name
+	dst.Age = src.Age
+	dst.Score = src.Score
+	dst.Active = src.Active
+	dst.Tags = src.Tags
+	dst.Limits = src.Limits
+	dst.Nickname = &src.Nickname
+	dst.Temperature = ParseTemperature(src.Temperature)
+	// ignored "Password"
+	return
+}
+func MapProfileBack(rnd ProfileDTO, back *Profile) {
+	if back == nil {
+		return
+	}
+	back.Name = rnd.Name
+	back.Age = rnd.Age
+	back.Score = rnd.Score
+	back.Active = rnd.Active
+	back.Tags = rnd.Tags
+	back.Limits = rnd.Limits
+	back.Nickname = &rnd.Nickname
+	back.Temperature = ParseTemperature(rnd.Temperature)
+	// ignored "Password"
+	return
+}
+func (src *Profile) ToDTO() ProfileDTO {
+	dst := ProfileDTO{}
+	dst.Name = src.Name
+	dst.Age = src.Age
+	dst.Score = src.Score
+	dst.Active = src.Active
+	dst.Tags = src.Tags
+	dst.Limits = src.Limits
+	dst.Nickname = *src.Nickname
+	dst.Temperature = src.Temperature.String()
+	return dst
+}
@@ -0,0 +1,211 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMapProfile(t *testing.T) {
+	var src ProfileDTO
+	src.Name = "src.Name 1"
+	src.Age = 2
+	src.Score = 3.5
+	src.Active = false
+	src.Tags = []string{"src.Tags 5"}
+	src.Limits = map[string]int{"src.Limits 6": 6}
+	src.Nickname = "src.Nickname 7"
+	src.Temperature = "src.Temperature 8"
+	dst := new(Profile)
+	MapProfile(src, dst)
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.Age != 2 {
+		t.Errorf("dst.Age = %#v, want %#v", dst.Age, 2)
+	}
+	if dst.Score != 3.5 {
+		t.Errorf("dst.Score = %#v, want %#v", dst.Score, 3.5)
+	}
+	if dst.Active {
+		t.Errorf("dst.Active = %#v, want %#v", dst.Active, false)
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"src.Tags 5"}) {
+		t.Errorf("dst.Tags = %#v, want %#v", dst.Tags, []string{"src.Tags 5"})
+	}
+	if !reflect.DeepEqual(dst.Limits, map[string]int{"src.Limits 6": 6}) {
+		t.Errorf("dst.Limits = %#v, want %#v", dst.Limits, map[string]int{"src.Limits 6": 6})
+	}
+	if dst.Nickname == nil || *dst.Nickname != "src.Nickname 7" {
+		t.Errorf("dst.Nickname does not point to %#v", "src.Nickname 7")
+	}
+}
+func TestMapProfileUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(Profile{}), false, "Name", "Age", "Score", "Active", "Tags", "Limits", "Nickname", "Temperature", "Password")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapProfileBack(t *testing.T) {
+	var rnd ProfileDTO
+	rnd.Name = "rnd.Name 1"
+	rnd.Age = 2
+	rnd.Score = 3.5
+	rnd.Active = false
+	rnd.Tags = []string{"rnd.Tags 5"}
+	rnd.Limits = map[string]int{"rnd.Limits 6": 6}
+	rnd.Nickname = "rnd.Nickname 7"
+	rnd.Temperature = "rnd.Temperature 8"
+	back := new(Profile)
+	MapProfileBack(rnd, back)
+	if back.Name != "rnd.Name 1" {
+		t.Errorf("back.Name = %#v, want %#v", back.Name, "rnd.Name 1")
+	}
+	if back.Age != 2 {
+		t.Errorf("back.Age = %#v, want %#v", back.Age, 2)
+	}
+	if back.Score != 3.5 {
+		t.Errorf("back.Score = %#v, want %#v", back.Score, 3.5)
+	}
+	if back.Active {
+		t.Errorf("back.Active = %#v, want %#v", back.Active, false)
+	}
+	if !reflect.DeepEqual(back.Tags, []string{"rnd.Tags 5"}) {
+		t.Errorf("back.Tags = %#v, want %#v", back.Tags, []string{"rnd.Tags 5"})
+	}
+	if !reflect.DeepEqual(back.Limits, map[string]int{"rnd.Limits 6": 6}) {
+		t.Errorf("back.Limits = %#v, want %#v", back.Limits, map[string]int{"rnd.Limits 6": 6})
+	}
+	if back.Nickname == nil || *back.Nickname != "rnd.Nickname 7" {
+		t.Errorf("back.Nickname does not point to %#v", "rnd.Nickname 7")
+	}
+}
+func TestMapProfileBackUpToDate(t *testing.T) {
+	fields := staleFields("back", reflect.TypeOf(Profile{}), false, "Name", "Age", "Score", "Active", "Tags", "Limits", "Nickname", "Temperature", "Password")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestToDTO(t *testing.T) {
+	src := new(Profile)
+	src.Name = "src.Name 1"
+	src.Age = 2
+	src.Score = 3.5
+	src.Active = false
+	src.Tags = []string{"src.Tags 5"}
+	src.Limits = map[string]int{"src.Limits 6": 6}
+	srcNickname := "src.Nickname 7"
+	src.Nickname = &srcNickname
+	src.Temperature = 8.5
+	src.Password = "src.Password 9"
+	dst := src.ToDTO()
+	if dst.Name != "src.Name 1" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "src.Name 1")
+	}
+	if dst.Age != 2 {
+		t.Errorf("dst.Age = %#v, want %#v", dst.Age, 2)
+	}
+	if dst.Score != 3.5 {
+		t.Errorf("dst.Score = %#v, want %#v", dst.Score, 3.5)
+	}
+	if dst.Active {
+		t.Errorf("dst.Active = %#v, want %#v", dst.Active, false)
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"src.Tags 5"}) {
+		t.Errorf("dst.Tags = %#v, want %#v", dst.Tags, []string{"src.Tags 5"})
+	}
+	if !reflect.DeepEqual(dst.Limits, map[string]int{"src.Limits 6": 6}) {
+		t.Errorf("dst.Limits = %#v, want %#v", dst.Limits, map[string]int{"src.Limits 6": 6})
+	}
+	if dst.Nickname != "src.Nickname 7" {
+		t.Errorf("dst.Nickname = %#v, want %#v", dst.Nickname, "src.Nickname 7")
+	}
+}
+func TestToDTOUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ProfileDTO{}), false, "Name", "Age", "Score", "Active", "Tags", "Limits", "Nickname", "Temperature")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapProfileRoundTrip(t *testing.T) {
+	// ignored "Password"
+	// converted "Temperature"
+	var src ProfileDTO
+	rnd := rand.New(rand.NewSource(1))
+	src.Name = strconv.Itoa(rnd.Int())
+	src.Age = Years(rnd.Intn(100))
+	src.Score = rnd.Float64()
+	src.Active = rnd.Intn(2) == 1
+	src.Tags = []string{strconv.Itoa(rnd.Int()), strconv.Itoa(rnd.Int())}
+	src.Limits = map[string]int{strconv.Itoa(rnd.Int()): rnd.Intn(100)}
+	src.Nickname = strconv.Itoa(rnd.Int())
+	src.Temperature = strconv.Itoa(rnd.Int())
+	dst := new(Profile)
+	MapProfile(src, dst)
+	back := dst.ToDTO()
+	if !reflect.DeepEqual(back.Name, src.Name) {
+		t.Errorf("back.Name = %#v, want %#v", back.Name, src.Name)
+	}
+	if !reflect.DeepEqual(back.Age, src.Age) {
+		t.Errorf("back.Age = %#v, want %#v", back.Age, src.Age)
+	}
+	if !reflect.DeepEqual(back.Score, src.Score) {
+		t.Errorf("back.Score = %#v, want %#v", back.Score, src.Score)
+	}
+	if !reflect.DeepEqual(back.Active, src.Active) {
+		t.Errorf("back.Active = %#v, want %#v", back.Active, src.Active)
+	}
+	if !reflect.DeepEqual(back.Tags, src.Tags) {
+		t.Errorf("back.Tags = %#v, want %#v", back.Tags, src.Tags)
+	}
+	if !reflect.DeepEqual(back.Limits, src.Limits) {
+		t.Errorf("back.Limits = %#v, want %#v", back.Limits, src.Limits)
+	}
+	if !reflect.DeepEqual(back.Nickname, src.Nickname) {
+		t.Errorf("back.Nickname = %#v, want %#v", back.Nickname, src.Nickname)
+	}
+}
+func TestMapProfileBackRoundTrip(t *testing.T) {
+	// ignored "Password"
+	// converted "Temperature"
+	var rnd ProfileDTO
+	rnd1 := rand.New(rand.NewSource(1))
+	rnd.Name = strconv.Itoa(rnd1.Int())
+	rnd.Age = Years(rnd1.Intn(100))
+	rnd.Score = rnd1.Float64()
+	rnd.Active = rnd1.Intn(2) == 1
+	rnd.Tags = []string{strconv.Itoa(rnd1.Int()), strconv.Itoa(rnd1.Int())}
+	rnd.Limits = map[string]int{strconv.Itoa(rnd1.Int()): rnd1.Intn(100)}
+	rnd.Nickname = strconv.Itoa(rnd1.Int())
+	rnd.Temperature = strconv.Itoa(rnd1.Int())
+	back := new(Profile)
+	MapProfileBack(rnd, back)
+	back1 := back.ToDTO()
+	if !reflect.DeepEqual(back1.Name, rnd.Name) {
+		t.Errorf("back1.Name = %#v, want %#v", back1.Name, rnd.Name)
+	}
+	if !reflect.DeepEqual(back1.Age, rnd.Age) {
+		t.Errorf("back1.Age = %#v, want %#v", back1.Age, rnd.Age)
+	}
+	if !reflect.DeepEqual(back1.Score, rnd.Score) {
+		t.Errorf("back1.Score = %#v, want %#v", back1.Score, rnd.Score)
+	}
+	if !reflect.DeepEqual(back1.Active, rnd.Active) {
+		t.Errorf("back1.Active = %#v, want %#v", back1.Active, rnd.Active)
+	}
+	if !reflect.DeepEqual(back1.Tags, rnd.Tags) {
+		t.Errorf("back1.Tags = %#v, want %#v", back1.Tags, rnd.Tags)
+	}
+	if !reflect.DeepEqual(back1.Limits, rnd.Limits) {
+		t.Errorf("back1.Limits = %#v, want %#v", back1.Limits, rnd.Limits)
+	}
+	if !reflect.DeepEqual(back1.Nickname, rnd.Nickname) {
+		t.Errorf("back1.Nickname = %#v, want %#v", back1.Nickname, rnd.Nickname)
+	}
+}
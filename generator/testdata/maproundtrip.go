@@ -0,0 +1,26 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapProfile(src ProfileDTO, dst *Profile) {
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(ParseTemperature)
+	typemapper.IgnoreFields(dst.Password)
+}
+
+func (src *Profile) ToDTO() ProfileDTO {
+	var dst ProfileDTO
+	typemapper.CreateMap(src, dst)
+	typemapper.MapWith(src.Temperature.String)
+	return dst
+}
+
+func MapProfileBack(rnd ProfileDTO, back *Profile) {
+	typemapper.CreateMap(rnd, back)
+	typemapper.MapWith(ParseTemperature)
+	typemapper.IgnoreFields(back.Password)
+}
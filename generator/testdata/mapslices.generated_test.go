@@ -1,15 +1,107 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package testdata
 
 import "testing"
 
-func TestMapSliceSrcDestParams(t *testing.T)                        {}
-func TestMapSliceSrcDestParamsError(t *testing.T)                   {}
-func TestMapSliceSrcParamsDestConst(t *testing.T)                   {}
-func TestMapSliceSrcParamsDestConstTypeAlias(t *testing.T)          {}
-func TestMapSliceSrcParamsDestConstTypeAliasError(t *testing.T)     {}
-func TestMapSliceSrcParamsTypeAliasDestConst(t *testing.T)          {}
-func TestMapSliceSrcParamsTypeAliasDestConstTypeAlias(t *testing.T) {}
+func TestMapSliceSrcDestParams(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst := make([]string, len(src))
+	MapSliceSrcDestParams(src, dst)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcDestParamsError(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst := make([]string, len(src))
+	err := MapSliceSrcDestParamsError(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcParamsDestConst(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst := MapSliceSrcParamsDestConst(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcParamsDestConstTypeAlias(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst := MapSliceSrcParamsDestConstTypeAlias(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcParamsDestConstTypeAliasError(t *testing.T) {
+	src := []string{"src[0] 1", "src[1] 2"}
+	dst, err := MapSliceSrcParamsDestConstTypeAliasError(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcParamsTypeAliasDestConst(t *testing.T) {
+	src := []stringAlias{"src[0] 1", "src[1] 2"}
+	dst := MapSliceSrcParamsTypeAliasDestConst(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
+func TestMapSliceSrcParamsTypeAliasDestConstTypeAlias(t *testing.T) {
+	src := []stringAlias{"src[0] 1", "src[1] 2"}
+	dst := MapSliceSrcParamsTypeAliasDestConstTypeAlias(src)
+	if len(dst) != len(src) {
+		t.Fatalf("len(dst) = %d, want %d", len(dst), len(src))
+	}
+	if dst[0] != "src[0] 1" {
+		t.Errorf("dst[0] = %#v, want %#v", dst[0], "src[0] 1")
+	}
+	if dst[1] != "src[1] 2" {
+		t.Errorf("dst[1] = %#v, want %#v", dst[1], "src[1] 2")
+	}
+}
@@ -1,4 +1,4 @@
-// +build typemapper
+//go:build typemapper
 
 package testdata
 
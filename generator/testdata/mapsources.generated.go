@@ -0,0 +1,34 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapUserView(user User, account *Account, settings Settings) UserView {
+	dst := UserView{}
+	dst.ID = user.ID
+	dst.Name = user.Name
+	dst.Balance = account.Balance
+	dst.AccountID = account.ID
+	dst.Theme = settings.Theme
+	dst.Locale = settings.Language
+	return dst
+}
+func MapUserViewOverrides(user User, defaults Settings, overrides Settings) UserView {
+	dst := UserView{}
+	dst.ID = user.ID
+	dst.Name = user.Name
+	dst.Theme = defaults.Theme
+	dst.Locale = overrides.Language
+	// ignored "Balance", "AccountID"
+	return dst
+}
+func MapUserViewUnmatched(user User, settings Settings) UserView {
+	dst := UserView{}
+	dst.ID = user.ID
+	dst.Name = user.Name
+	dst.Theme = settings.Theme
+	// no match for "Locale" in user, settings
+	// ignored "Balance", "AccountID"
+	return dst
+}
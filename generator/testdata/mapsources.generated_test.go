@@ -0,0 +1,87 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapUserView(t *testing.T) {
+	var user User
+	account := new(Account)
+	var settings Settings
+	user.ID = "user.ID 1"
+	user.Name = "user.Name 2"
+	account.ID = "account.ID 3"
+	account.Balance = 4
+	settings.Theme = "settings.Theme 5"
+	settings.Language = "settings.Language 6"
+	dst := MapUserView(user, account, settings)
+	if dst.ID != "user.ID 1" {
+		t.Errorf("dst.ID = %#v, want %#v", dst.ID, "user.ID 1")
+	}
+	if dst.Name != "user.Name 2" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "user.Name 2")
+	}
+	if dst.Balance != 4 {
+		t.Errorf("dst.Balance = %#v, want %#v", dst.Balance, 4)
+	}
+	if dst.AccountID != "account.ID 3" {
+		t.Errorf("dst.AccountID = %#v, want %#v", dst.AccountID, "account.ID 3")
+	}
+	if dst.Theme != "settings.Theme 5" {
+		t.Errorf("dst.Theme = %#v, want %#v", dst.Theme, "settings.Theme 5")
+	}
+	if dst.Locale != "settings.Language 6" {
+		t.Errorf("dst.Locale = %#v, want %#v", dst.Locale, "settings.Language 6")
+	}
+}
+func TestMapUserViewUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(UserView{}), false, "ID", "Name", "Balance", "AccountID", "Theme", "Locale")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapUserViewOverrides(t *testing.T) {
+	var user User
+	var defaults Settings
+	var overrides Settings
+	user.ID = "user.ID 1"
+	user.Name = "user.Name 2"
+	defaults.Theme = "defaults.Theme 3"
+	defaults.Language = "defaults.Language 4"
+	overrides.Theme = "overrides.Theme 5"
+	overrides.Language = "overrides.Language 6"
+	dst := MapUserViewOverrides(user, defaults, overrides)
+	if dst.ID != "user.ID 1" {
+		t.Errorf("dst.ID = %#v, want %#v", dst.ID, "user.ID 1")
+	}
+	if dst.Name != "user.Name 2" {
+		t.Errorf("dst.Name = %#v, want %#v", dst.Name, "user.Name 2")
+	}
+	if dst.Theme != "defaults.Theme 3" {
+		t.Errorf("dst.Theme = %#v, want %#v", dst.Theme, "defaults.Theme 3")
+	}
+	if dst.Locale != "overrides.Language 6" {
+		t.Errorf("dst.Locale = %#v, want %#v", dst.Locale, "overrides.Language 6")
+	}
+}
+func TestMapUserViewOverridesUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(UserView{}), false, "ID", "Name", "Balance", "AccountID", "Theme", "Locale")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapUserViewUnmatched(t *testing.T) {
+	t.Fatal("no mapping for: [Locale] in user, settings")
+}
+func TestMapUserViewUnmatchedUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(UserView{}), false, "ID", "Name", "Balance", "AccountID", "Theme", "Locale")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
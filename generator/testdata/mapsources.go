@@ -0,0 +1,35 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapUserView(user User, account *Account, settings Settings) UserView {
+	var dst UserView
+	typemapper.CreateMap(user, dst)
+	typemapper.AddSource(account)
+	typemapper.AddSource(settings)
+	typemapper.MapField(account.ID, dst.AccountID)
+	typemapper.MapField(settings.Language, dst.Locale)
+	return dst
+}
+
+func MapUserViewUnmatched(user User, settings Settings) UserView {
+	var dst UserView
+	typemapper.CreateMap(user, dst)
+	typemapper.AddSource(settings)
+	typemapper.IgnoreFields(dst.Balance, dst.AccountID)
+	return dst
+}
+
+func MapUserViewOverrides(user User, defaults Settings, overrides Settings) UserView {
+	var dst UserView
+	typemapper.CreateMap(user, dst)
+	typemapper.AddSource(defaults)
+	typemapper.AddSource(overrides)
+	typemapper.MapField(overrides.Language, dst.Locale)
+	typemapper.IgnoreFields(dst.Balance, dst.AccountID)
+	return dst
+}
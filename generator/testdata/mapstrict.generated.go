@@ -0,0 +1,21 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+func MapPersonSummaryStrict(src Person) PersonSummary {
+	dst := PersonSummary{}
+	dst.Name = src.FullName()
+	dst.Initials = src.First[:1] + src.Last[:1]
+	dst.ItemCount = len(src.Items)
+	dst.Email = src.Email
+	// ignored "Domain", "FirstTag", "Label"
+	return dst
+}
+func MapServiceViewUnused(src ServiceRecord) ServiceView {
+	dst := ServiceView{}
+	dst.Name = src.Name
+	// unused source fields src.ServiceName
+	return dst
+}
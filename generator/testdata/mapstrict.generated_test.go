@@ -0,0 +1,41 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapPersonSummaryStrict(t *testing.T) {
+	var src Person
+	src.First = "src.First 1"
+	src.Last = "src.Last 2"
+	src.Items = []string{"src.Items 3"}
+	src.Email = "src.Email 4"
+	src.Tags = map[string]string{"src.Tags 5": "src.Tags 5"}
+	dst := MapPersonSummaryStrict(src)
+	if dst.Email != "src.Email 4" {
+		t.Errorf("dst.Email = %#v, want %#v", dst.Email, "src.Email 4")
+	}
+}
+func TestMapPersonSummaryStrictUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(PersonSummary{}), false, "Name", "Initials", "ItemCount", "Domain", "Email", "FirstTag", "Label")
+	fields = append(fields, staleFields("src", reflect.TypeOf(Person{}), false, "First", "Last", "Items", "Email", "Tags")...)
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapServiceViewUnused(t *testing.T) {
+	t.Fatal("unused source fields: [src.ServiceName]")
+}
+func TestMapServiceViewUnusedUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(ServiceView{}), false, "Name")
+	fields = append(fields, staleFields("src", reflect.TypeOf(ServiceRecord{}), false, "ServiceName", "Name")...)
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,26 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapPersonSummaryStrict(src Person) PersonSummary {
+	var dst PersonSummary
+	typemapper.CreateMap(src, dst)
+	typemapper.Strict()
+	typemapper.MapField(src.FullName(), dst.Name)
+	typemapper.MapField(src.First[:1]+src.Last[:1], dst.Initials)
+	typemapper.MapField(len(src.Items), dst.ItemCount)
+	typemapper.IgnoreFields(dst.Domain, dst.FirstTag, dst.Label)
+	typemapper.IgnoreSourceFields(src.Tags)
+	return dst
+}
+
+func MapServiceViewUnused(src ServiceRecord) ServiceView {
+	var dst ServiceView
+	typemapper.CreateMap(src, dst)
+	typemapper.Strict()
+	return dst
+}
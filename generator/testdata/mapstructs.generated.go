@@ -1,9 +1,20 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package testdata
 
+func MapFuzzNamesError(src FuzzNames, dst *FuzzNames) error {
+	if dst == nil {
+		return nil
+	}
+	dst.Count = src.Count
+	dst.CountSet = src.CountSet
+	dst.X = src.X
+	dst.A = src.A
+	dst.AB = src.AB
+	return nil
+}
 func MapStructPtrSrcDestParams(src *SourceStruct, dst *DestStruct) {
 	if dst == nil {
 		return
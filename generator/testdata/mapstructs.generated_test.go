@@ -1,21 +1,507 @@
 // Code generated by "typemapper "; DO NOT EDIT.
 
-// +build !typemapper
+//go:build !typemapper
 
 package testdata
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
-func TestMapStructPtrSrcDestParams(t *testing.T)            {}
-func TestMapStructPtrSrcParamsDestConst(t *testing.T)       {}
-func TestMapStructPtrSrcRecvDestConst(t *testing.T)         {}
-func TestMapStructPtrSrcRecvPtrDestConst(t *testing.T)      {}
-func TestMapStructPtrSrcRecvPtrDestConstError(t *testing.T) {}
-func TestMapStructSrcDestParams(t *testing.T)               {}
-func TestMapStructSrcDstParamsError(t *testing.T)           {}
-func TestMapStructSrcParamsDestConst(t *testing.T)          {}
-func TestMapStructSrcParamsDestConstError(t *testing.T)     {}
-func TestMapStructSrcParamsPtrDestConst(t *testing.T)       {}
-func TestMapStructSrcRecvDestConst(t *testing.T)            {}
-func TestMapStructSrcRecvDestParams(t *testing.T)           {}
-func TestMapStructSrcRecvPtrDestConst(t *testing.T)         {}
+func TestMapFuzzNamesError(t *testing.T) {
+	var src FuzzNames
+	srcCount := 1
+	src.Count = &srcCount
+	src.CountSet = false
+	src.X = 3
+	dst := new(FuzzNames)
+	err := MapFuzzNamesError(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.Count == nil || *dst.Count != 1 {
+		t.Errorf("dst.Count does not point to %#v", 1)
+	}
+	if dst.CountSet {
+		t.Errorf("dst.CountSet = %#v, want %#v", dst.CountSet, false)
+	}
+	if dst.X != 3 {
+		t.Errorf("dst.X = %#v, want %#v", dst.X, 3)
+	}
+}
+func TestMapFuzzNamesErrorUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(FuzzNames{}), false, "Count", "CountSet", "X", "A", "AB")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructPtrSrcDestParams(t *testing.T) {
+	src := new(SourceStruct)
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := new(DestStruct)
+	MapStructPtrSrcDestParams(src, dst)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructPtrSrcDestParamsUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructPtrSrcParamsDestConst(t *testing.T) {
+	src := new(SourceStruct)
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := MapStructPtrSrcParamsDestConst(src)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructPtrSrcParamsDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructPtrSrcRecvDestConst(t *testing.T) {
+	src := new(SourceStruct)
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := src.MapStructPtrSrcRecvDestConst()
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructPtrSrcRecvDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructPtrSrcRecvPtrDestConst(t *testing.T) {
+	src := new(SourceStruct)
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := src.MapStructPtrSrcRecvPtrDestConst()
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructPtrSrcRecvPtrDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructPtrSrcRecvPtrDestConstError(t *testing.T) {
+	src := new(SourceStruct)
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst, err := src.MapStructPtrSrcRecvPtrDestConstError()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructPtrSrcRecvPtrDestConstErrorUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcDestParams(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := new(DestStruct)
+	MapStructSrcDestParams(src, dst)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcDestParamsUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcDstParamsError(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := new(DestStruct)
+	err := MapStructSrcDstParamsError(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcDstParamsErrorUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcParamsDestConst(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := MapStructSrcParamsDestConst(src)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcParamsDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcParamsDestConstError(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst, err := MapStructSrcParamsDestConstError(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcParamsDestConstErrorUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcParamsPtrDestConst(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := MapStructSrcParamsPtrDestConst(src)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcParamsPtrDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcRecvDestConst(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := src.MapStructSrcRecvDestConst()
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcRecvDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcRecvDestParams(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := new(DestStruct)
+	src.MapStructSrcRecvDestParams(dst)
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcRecvDestParamsUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapStructSrcRecvPtrDestConst(t *testing.T) {
+	var src SourceStruct
+	src.StringMatch = "src.StringMatch 1"
+	src.IntMatch = 2
+	src.BoolMatch = true
+	src.PointerMatch = "src.PointerMatch 4"
+	srcDerefMatch := "src.DerefMatch 5"
+	src.DerefMatch = &srcDerefMatch
+	src.TypeAliasMatch = "src.TypeAliasMatch 6"
+	dst := src.MapStructSrcRecvPtrDestConst()
+	if dst.StringMatch != "src.StringMatch 1" {
+		t.Errorf("dst.StringMatch = %#v, want %#v", dst.StringMatch, "src.StringMatch 1")
+	}
+	if dst.IntMatch != 2 {
+		t.Errorf("dst.IntMatch = %#v, want %#v", dst.IntMatch, 2)
+	}
+	if !dst.BoolMatch {
+		t.Errorf("dst.BoolMatch = %#v, want %#v", dst.BoolMatch, true)
+	}
+	if dst.PointerMatch == nil || *dst.PointerMatch != "src.PointerMatch 4" {
+		t.Errorf("dst.PointerMatch does not point to %#v", "src.PointerMatch 4")
+	}
+	if dst.DerefMatch != "src.DerefMatch 5" {
+		t.Errorf("dst.DerefMatch = %#v, want %#v", dst.DerefMatch, "src.DerefMatch 5")
+	}
+	if dst.TypeAliasMatch != "src.TypeAliasMatch 6" {
+		t.Errorf("dst.TypeAliasMatch = %#v, want %#v", dst.TypeAliasMatch, "src.TypeAliasMatch 6")
+	}
+}
+func TestMapStructSrcRecvPtrDestConstUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(DestStruct{}), false, "StringMatch", "IntMatch", "BoolMatch", "PointerMatch", "DerefMatch", "TypeAliasMatch")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
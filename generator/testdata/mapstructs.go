@@ -1,4 +1,4 @@
-// +build typemapper
+//go:build typemapper
 
 package testdata
 
@@ -43,6 +43,11 @@ func MapStructSrcDstParamsError(src SourceStruct, dst *DestStruct) error {
 	return nil
 }
 
+func MapFuzzNamesError(src FuzzNames, dst *FuzzNames) error {
+	typemapper.CreateMap(src, dst)
+	return nil
+}
+
 func (src SourceStruct) MapStructSrcRecvDestParams(dst *DestStruct) {
 	typemapper.CreateMap(src, dst)
 }
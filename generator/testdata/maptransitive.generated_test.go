@@ -0,0 +1,51 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapLayerDomainToDB(t *testing.T) {
+	var src LayerDomain
+	srcID := "src.ID 1"
+	src.ID = &srcID
+	dst := MapLayerDomainToDB(src)
+	if dst.ID != "src.ID 1" {
+		t.Errorf("dst.ID = %#v, want %#v", dst.ID, "src.ID 1")
+	}
+}
+func TestMapLayerDomainToDBUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(LayerDB{}), false, "ID", "Version")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapLayerProtoParentToDBParent(t *testing.T) {
+	var src LayerProtoParent
+	_ = MapLayerProtoParentToDBParent(src)
+}
+func TestMapLayerProtoParentToDBParentUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(LayerDBParent{}), false, "Layer", "Layers")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapLayerProtoToDomain(t *testing.T) {
+	var src LayerProto
+	src.ID = "src.ID 1"
+	dst := MapLayerProtoToDomain(src)
+	if dst.ID == nil || *dst.ID != "src.ID 1" {
+		t.Errorf("dst.ID does not point to %#v", "src.ID 1")
+	}
+}
+func TestMapLayerProtoToDomainUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(LayerDomain{}), false, "ID")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,32 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapLayerProtoToDomain(src LayerProto) LayerDomain {
+	var dst LayerDomain
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapLayerDomainToDB(src LayerDomain) LayerDB {
+	var dst LayerDB
+	typemapper.CreateMap(src, dst)
+	typemapper.IgnoreFields(dst.Version)
+	return dst
+}
+
+func MapLayerProtoToDB(src LayerProto, dst *LayerDB) {
+	typemapper.CreateMap(src, dst)
+	typemapper.MapTransitively()
+}
+
+func MapLayerProtoParentToDBParent(src LayerProtoParent) LayerDBParent {
+	var dst LayerDBParent
+	typemapper.CreateMap(src, dst)
+	typemapper.MapTransitively()
+	return dst
+}
@@ -0,0 +1,148 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import "database/sql"
+
+func MapAccountModel(src AccountRow) AccountModel {
+	dst := AccountModel{}
+	dst.Name = src.Name.String
+	if src.Email.Valid {
+		p := src.Email.String
+		dst.Email = &p
+	}
+	if src.Age.Valid {
+		p := Years(src.Age.Int64)
+		dst.Age = &p
+	}
+	if src.Deleted.Valid {
+		p := src.Deleted.Time
+		dst.Deleted = &p
+	}
+	if v, ok := src.Nickname.Get(); ok {
+		dst.Nickname = &v
+	}
+	if src.Manager.Valid {
+		dst.Manager.Set(src.Manager.V)
+	}
+	return dst
+}
+func MapAccountModelShadowed(p AccountRow) AccountModel {
+	dst := AccountModel{}
+	dst.Name = p.Name.String
+	if p.Email.Valid {
+		p1 := p.Email.String
+		dst.Email = &p1
+	}
+	if p.Age.Valid {
+		p1 := Years(p.Age.Int64)
+		dst.Age = &p1
+	}
+	if p.Deleted.Valid {
+		p1 := p.Deleted.Time
+		dst.Deleted = &p1
+	}
+	if v, ok := p.Nickname.Get(); ok {
+		dst.Nickname = &v
+	}
+	if p.Manager.Valid {
+		dst.Manager.Set(p.Manager.V)
+	}
+	return dst
+}
+func MapAccountRow(src AccountModel, dst *AccountRow) {
+	if dst == nil {
+		return
+	}
+	dst.Name = sql.NullString{
+		String: src.Name,
+		Valid:  true,
+	}
+	if src.Email != nil {
+		dst.Email = sql.NullString{
+			String: *src.Email,
+			Valid:  true,
+		}
+	} else {
+		dst.Email = sql.NullString{}
+	}
+	if src.Age != nil {
+		dst.Age = sql.NullInt64{
+			Int64: int64(*src.Age),
+			Valid: true,
+		}
+	} else {
+		dst.Age = sql.NullInt64{}
+	}
+	if src.Deleted != nil {
+		dst.Deleted = sql.NullTime{
+			Time:  *src.Deleted,
+			Valid: true,
+		}
+	} else {
+		dst.Deleted = sql.NullTime{}
+	}
+	if src.Nickname != nil {
+		dst.Nickname.Set(*src.Nickname)
+	} else {
+		dst.Nickname = Maybe[string]{}
+	}
+	if v, ok := src.Manager.Get(); ok {
+		dst.Manager = sql.Null[string]{
+			V:     v,
+			Valid: true,
+		}
+	} else {
+		dst.Manager = sql.Null[string]{}
+	}
+	return
+}
+func MapAccountRowShadowed(v AccountModel, ok *AccountRow) {
+	if ok == nil {
+		return
+	}
+	ok.Name = sql.NullString{
+		String: v.Name,
+		Valid:  true,
+	}
+	if v.Email != nil {
+		ok.Email = sql.NullString{
+			String: *v.Email,
+			Valid:  true,
+		}
+	} else {
+		ok.Email = sql.NullString{}
+	}
+	if v.Age != nil {
+		ok.Age = sql.NullInt64{
+			Int64: int64(*v.Age),
+			Valid: true,
+		}
+	} else {
+		ok.Age = sql.NullInt64{}
+	}
+	if v.Deleted != nil {
+		ok.Deleted = sql.NullTime{
+			Time:  *v.Deleted,
+			Valid: true,
+		}
+	} else {
+		ok.Deleted = sql.NullTime{}
+	}
+	if v.Nickname != nil {
+		ok.Nickname.Set(*v.Nickname)
+	} else {
+		ok.Nickname = Maybe[string]{}
+	}
+	if v1, ok1 := v.Manager.Get(); ok1 {
+		ok.Manager = sql.Null[string]{
+			V:     v1,
+			Valid: true,
+		}
+	} else {
+		ok.Manager = sql.Null[string]{}
+	}
+	return
+}
@@ -0,0 +1,84 @@
+// Code generated by "typemapper "; DO NOT EDIT.
+
+//go:build !typemapper
+
+package testdata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapAccountModel(t *testing.T) {
+	var src AccountRow
+	_ = MapAccountModel(src)
+}
+func TestMapAccountModelUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(AccountModel{}), false, "Name", "Email", "Age", "Deleted", "Nickname", "Manager")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapAccountModelShadowed(t *testing.T) {
+	var p AccountRow
+	_ = MapAccountModelShadowed(p)
+}
+func TestMapAccountModelShadowedUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(AccountModel{}), false, "Name", "Email", "Age", "Deleted", "Nickname", "Manager")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapAccountRow(t *testing.T) {
+	var src AccountModel
+	src.Name = "src.Name 1"
+	srcEmail := "src.Email 2"
+	src.Email = &srcEmail
+	srcAge := Years(3)
+	src.Age = &srcAge
+	srcNickname := "src.Nickname 4"
+	src.Nickname = &srcNickname
+	dst := new(AccountRow)
+	MapAccountRow(src, dst)
+}
+func TestMapAccountRowUpToDate(t *testing.T) {
+	fields := staleFields("dst", reflect.TypeOf(AccountRow{}), false, "Name", "Email", "Age", "Deleted", "Nickname", "Manager")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapAccountRowShadowed(t *testing.T) {
+	var v AccountModel
+	v.Name = "v.Name 1"
+	vEmail := "v.Email 2"
+	v.Email = &vEmail
+	vAge := Years(3)
+	v.Age = &vAge
+	vNickname := "v.Nickname 4"
+	v.Nickname = &vNickname
+	ok := new(AccountRow)
+	MapAccountRowShadowed(v, ok)
+}
+func TestMapAccountRowShadowedUpToDate(t *testing.T) {
+	fields := staleFields("ok", reflect.TypeOf(AccountRow{}), false, "Name", "Email", "Age", "Deleted", "Nickname", "Manager")
+	if len(fields) > 0 {
+		t.Errorf("regenerate: new fields %s", strings.Join(fields, ", "))
+	}
+}
+func TestMapAccountModelMapAccountRowRoundTrip(t *testing.T) {
+	// converted "Name", "Email", "Age", "Deleted", "Nickname", "Manager"
+	t.Skip("no fields mapped both ways")
+}
+func TestMapAccountModelMapAccountRowShadowedRoundTrip(t *testing.T) {
+	// converted "Name", "Email", "Age", "Deleted", "Nickname", "Manager"
+	t.Skip("no fields mapped both ways")
+}
+func TestMapAccountModelShadowedMapAccountRowRoundTrip(t *testing.T) {
+	// converted "Name", "Email", "Age", "Deleted", "Nickname", "Manager"
+	t.Skip("no fields mapped both ways")
+}
+func TestMapAccountModelShadowedMapAccountRowShadowedRoundTrip(t *testing.T) {
+	// converted "Name", "Email", "Age", "Deleted", "Nickname", "Manager"
+	t.Skip("no fields mapped both ways")
+}
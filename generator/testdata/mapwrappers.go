@@ -0,0 +1,27 @@
+//go:build typemapper
+
+package testdata
+
+import (
+	typemapper "github.com/paultyng/go-typemapper"
+)
+
+func MapAccountModel(src AccountRow) AccountModel {
+	var dst AccountModel
+	typemapper.CreateMap(src, dst)
+	return dst
+}
+
+func MapAccountRow(src AccountModel, dst *AccountRow) {
+	typemapper.CreateMap(src, dst)
+}
+
+func MapAccountModelShadowed(p AccountRow) AccountModel {
+	var dst AccountModel
+	typemapper.CreateMap(p, dst)
+	return dst
+}
+
+func MapAccountRowShadowed(v AccountModel, ok *AccountRow) {
+	typemapper.CreateMap(v, ok)
+}
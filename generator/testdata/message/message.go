@@ -0,0 +1,12 @@
+// Package message has a struct with unexported bookkeeping fields, like
+// generated protobuf messages.
+package message
+
+type Message struct {
+	state         int32
+	sizeCache     int32
+	unknownFields []byte
+
+	Name string
+	Body string
+}
@@ -1,5 +1,15 @@
 package testdata
 
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"example.com/testdata/convert"
+)
+
 type SourceStruct struct {
 	StringMatch string
 	IntMatch    int
@@ -23,3 +33,421 @@ type DestStruct struct {
 }
 
 type stringAlias string
+
+type Optional[T any] struct {
+	Value T
+	Valid bool
+}
+
+type OptionalSourceStruct struct {
+	StringMatch  Optional[string]
+	PointerMatch Optional[string]
+}
+
+type OptionalDestStruct struct {
+	StringMatch  Optional[string]
+	PointerMatch *Optional[string]
+}
+
+type Page[T any] struct {
+	Items []T
+	Total int
+}
+
+type ConvertSourceStruct struct {
+	Created     time.Time
+	Count       int
+	Temperature convert.Temperature
+	Name        string
+}
+
+type ConvertDestStruct struct {
+	Created     string
+	Count       string
+	Temperature string
+	Name        string
+}
+
+type Forecast struct {
+	High convert.Fahrenheit
+}
+
+type ForecastView struct {
+	High convert.Temperature
+}
+
+type ChildSourceStruct struct {
+	Name string
+}
+
+type ChildDestStruct struct {
+	Name *string
+}
+
+type ParentSourceStruct struct {
+	Child    ChildSourceStruct
+	Children []ChildSourceStruct
+}
+
+type ParentDestStruct struct {
+	Child    ChildDestStruct
+	Children []ChildDestStruct
+}
+
+type LayerProto struct {
+	ID string
+}
+
+type LayerDomain struct {
+	ID *string
+}
+
+type LayerDB struct {
+	ID      string
+	Version int
+}
+
+type LayerProtoParent struct {
+	Layer  LayerProto
+	Layers []LayerProto
+}
+
+type LayerDBParent struct {
+	Layer  LayerDB
+	Layers []LayerDB
+}
+
+type User struct {
+	ID   string
+	Name string
+}
+
+type Account struct {
+	ID      string
+	Balance int
+}
+
+type Settings struct {
+	Theme    string
+	Language string
+}
+
+type UserView struct {
+	ID        string
+	Name      string
+	Balance   int
+	AccountID string
+	Theme     string
+	Locale    string
+}
+
+type NameLookup map[string]string
+
+type Customer struct {
+	ID   string
+	Name string
+}
+
+type Product struct {
+	SKU  string
+	Name string
+}
+
+func ResolveCustomer(ctx context.Context, names NameLookup, id string) Customer {
+	return Customer{ID: id, Name: names[id]}
+}
+
+func ResolveProduct(names NameLookup, sku string) Product {
+	return Product{SKU: sku, Name: names[sku]}
+}
+
+type Order struct {
+	Customer string
+	Lines    []OrderLine
+}
+
+type OrderLine struct {
+	Product string
+}
+
+type OrderView struct {
+	Customer Customer
+	Lines    []OrderLineView
+}
+
+type OrderLineView struct {
+	Product Product
+}
+
+// Maybe is an optional value accessed with Get and Set.
+type Maybe[T any] struct {
+	value T
+	set   bool
+}
+
+func (m Maybe[T]) Get() (T, bool) {
+	return m.value, m.set
+}
+
+func (m *Maybe[T]) Set(v T) {
+	m.value, m.set = v, true
+}
+
+type Years int64
+
+type AccountRow struct {
+	Name     sql.NullString
+	Email    sql.NullString
+	Age      sql.NullInt64
+	Deleted  sql.NullTime
+	Nickname Maybe[string]
+	Manager  sql.Null[string]
+}
+
+type AccountModel struct {
+	Name     string
+	Email    *string
+	Age      *Years
+	Deleted  *time.Time
+	Nickname *string
+	Manager  Maybe[string]
+}
+
+type Labels map[string]string
+
+type Secret struct {
+	Key []byte
+}
+
+func CopySecret(s Secret) Secret {
+	return Secret{Key: append([]byte(nil), s.Key...)}
+}
+
+type TreeNode struct {
+	Name     string
+	Tags     []string
+	Labels   Labels
+	Parent   *TreeNode
+	Children []*TreeNode
+	Created  time.Time
+	Weights  [2][]int
+	Secret   Secret
+}
+
+type Tree struct {
+	Root   *TreeNode
+	Index  map[string]*TreeNode
+	Secret Secret
+	Name   *string
+	Owner  struct {
+		Name *string `json:"name"`
+	}
+}
+
+type TreeCopy struct {
+	Root   *TreeNode
+	Index  map[string]*TreeNode
+	Secret Secret
+	Name   string
+	Owner  struct {
+		Name *string `json:"name"`
+	}
+}
+
+type Response struct {
+	Items  []string
+	Meta   map[string]string
+	Owner  *Customer
+	Root   TreeNode
+	Status string
+}
+
+type ResponseView struct {
+	Items  []string
+	Meta   map[string]string
+	Owner  *Customer
+	Root   TreeNode
+	Status *string
+}
+
+type Entity struct {
+	ID      string
+	Version int
+}
+
+type Audit struct {
+	CreatedBy string
+	Note      string
+}
+
+type Tagged struct {
+	Note string
+}
+
+type Document struct {
+	Entity
+	*Audit
+	Tagged
+	Title   string
+	Version int
+}
+
+type EntityView struct {
+	ID string
+}
+
+type DocumentView struct {
+	*EntityView
+	Title     string
+	Version   int
+	CreatedBy string
+	Note      string
+}
+
+type DocumentRecord struct {
+	Entity
+	*Audit
+	Title string
+}
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Meta struct {
+	CreatedBy string
+	UpdatedBy string
+	Revision  int
+}
+
+type Contact struct {
+	Name    string
+	Author  string
+	Address *Address
+	Meta    Meta
+}
+
+type ContactView struct {
+	Name string
+	City string
+	Meta *Meta
+}
+
+type Person struct {
+	First string
+	Last  string
+	Items []string
+	Email string
+	Tags  map[string]string
+}
+
+func (p Person) FullName() string {
+	return p.First + " " + p.Last
+}
+
+type PersonSummary struct {
+	Name      string
+	Initials  string
+	ItemCount int
+	Domain    *string
+	Email     string
+	FirstTag  string
+	Label     string
+}
+
+type CounterMessage struct {
+	Name             string
+	Count            int
+	Lock             sync.Mutex
+	XXX_unrecognized []byte
+	XXX_sizecache    int32
+}
+
+type CounterState struct {
+	Name             string
+	Count            int
+	InternalVersion  int
+	Lock             sync.Mutex
+	XXX_unrecognized []byte
+	XXX_sizecache    int32
+}
+
+type MessageDraft struct {
+	Name      string
+	Body      string
+	sizeCache int32
+}
+
+type ServiceRecord struct {
+	ServiceName string
+	Name        string
+}
+
+type ServiceView struct {
+	Name string
+}
+
+type Reading struct {
+	Sensor string
+	Value  string
+}
+
+type ReadingView struct {
+	Sensor string
+	Value  float64
+}
+
+type Measurement struct {
+	Celsius float64
+}
+
+type MeasurementView struct {
+	Fahrenheit float64
+	Scaled     float64
+}
+
+type Profile struct {
+	Name        string
+	Age         Years
+	Score       float64
+	Active      bool
+	Tags        []string
+	Limits      map[string]int
+	Nickname    *string
+	Temperature convert.Temperature
+	Password    string
+}
+
+type ProfileDTO struct {
+	Name        string
+	Age         Years
+	Score       float64
+	Active      bool
+	Tags        []string
+	Limits      map[string]int
+	Nickname    string
+	Temperature string
+}
+
+func ParseTemperature(s string) convert.Temperature {
+	f, _ := strconv.ParseFloat(s, 64)
+	return convert.Temperature(f)
+}
+
+// FuzzNames has fields whose fuzz argument names collide.
+type FuzzNames struct {
+	Count    *int
+	CountSet bool
+	X        int
+	A        FuzzNamesA
+	AB       FuzzNamesAB
+}
+
+type FuzzNamesA struct {
+	BC string
+}
+
+type FuzzNamesAB struct {
+	C string
+}
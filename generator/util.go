@@ -14,6 +14,17 @@ func unwrapPointer(v types.Type) types.Type {
 	return v
 }
 
+// unwrapType follows pointers and named types to the underlying type.
+func unwrapType(v types.Type) types.Type {
+	switch v := v.(type) {
+	case *types.Pointer:
+		return unwrapType(v.Elem())
+	case *types.Named:
+		return unwrapType(v.Underlying())
+	}
+	return v
+}
+
 func isPointer(t types.Type) bool {
 	if _, ok := t.(*types.Pointer); ok {
 		return true
@@ -40,6 +51,15 @@ func unwrapSlice(v types.Type) *types.Slice {
 	return unwrap(v)
 }
 
+// sliceType returns the slice underlying v, without following pointers.
+func sliceType(v types.Type) *types.Slice {
+	if v == nil {
+		return nil
+	}
+	s, _ := v.Underlying().(*types.Slice)
+	return s
+}
+
 func unwrapStruct(v types.Type) *types.Struct {
 	if v == nil {
 		return nil
@@ -58,3 +78,13 @@ func unwrapStruct(v types.Type) *types.Struct {
 	}
 	return unwrap(v)
 }
+
+// isContext reports if t is context.Context.
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+}
@@ -0,0 +1,217 @@
+package generator
+
+import (
+	"go/types"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+const validField = "Valid"
+
+// wrapper describes a named type holding an optional value, either in a value
+// field next to a Valid field like sql.NullString, or behind Get and Set
+// methods.
+type wrapper struct {
+	valueType  types.Type
+	valueField string
+
+	// get returns the value and if it is set, set stores a value
+	get, set string
+}
+
+func wrapperOf(ty types.Type) *wrapper {
+	named, ok := ty.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if w := methodWrapper(named); w != nil {
+		return w
+	}
+	return fieldWrapper(named)
+}
+
+// methodWrapper matches types with `Get() (T, bool)` and `Set(T)` methods.
+func methodWrapper(named *types.Named) *wrapper {
+	mset := types.NewMethodSet(types.NewPointer(named))
+	get, set := mset.Lookup(nil, "Get"), mset.Lookup(nil, "Set")
+	if get == nil || set == nil {
+		return nil
+	}
+
+	getSig := get.Type().(*types.Signature)
+	setSig := set.Type().(*types.Signature)
+	if getSig.Params().Len() != 0 || getSig.Results().Len() != 2 ||
+		!types.Identical(getSig.Results().At(1).Type(), types.Typ[types.Bool]) {
+		return nil
+	}
+	valueType := getSig.Results().At(0).Type()
+	if setSig.Params().Len() != 1 || setSig.Results().Len() != 0 ||
+		!types.Identical(setSig.Params().At(0).Type(), valueType) {
+		return nil
+	}
+
+	return &wrapper{
+		valueType: valueType,
+		get:       "Get",
+		set:       "Set",
+	}
+}
+
+// fieldWrapper matches structs with a `Valid bool` field and a single other
+// exported value field.
+func fieldWrapper(named *types.Named) *wrapper {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 2 {
+		return nil
+	}
+
+	var valid, value *types.Var
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == validField {
+			valid = f
+			continue
+		}
+		value = f
+	}
+	if valid == nil || value == nil || !value.Exported() || value.Embedded() ||
+		!types.Identical(valid.Type(), types.Typ[types.Bool]) {
+		return nil
+	}
+
+	return &wrapper{
+		valueType:  value.Type(),
+		valueField: value.Name(),
+	}
+}
+
+// wrapperConvertible reports if srcType can be assigned to dstType by
+// unwrapping or wrapping an optional value.
+func (g *Generator) wrapperConvertible(mf *mappingFunc, srcType, dstType types.Type) bool {
+	if types.AssignableTo(unwrapType(srcType), unwrapType(dstType)) {
+		return false
+	}
+	if mw, err := g.converter(mf, srcType, dstType); mw != nil || err != nil {
+		return false
+	}
+
+	srcWrapper, dstWrapper := wrapperOf(srcType), wrapperOf(dstType)
+	if srcWrapper == nil && dstWrapper == nil {
+		return false
+	}
+
+	from, to := unwrapPointer(srcType), unwrapPointer(dstType)
+	if srcWrapper != nil {
+		from = srcWrapper.valueType
+	}
+	if dstWrapper != nil {
+		to = dstWrapper.valueType
+	}
+	if types.AssignableTo(unwrapType(from), unwrapType(to)) {
+		return true
+	}
+	mw, err := g.converter(mf, from, to)
+	return mw != nil && err == nil
+}
+
+// wrapperAssignment assigns srcExpr to dstExpr when either type is a wrapper,
+// only setting the destination when the source value is set. It returns nil
+// if no wrapper conversion applies.
+func (g *Generator) wrapperAssignment(mf *mappingFunc, srcExpr, dstExpr *Statement, srcType, dstType types.Type) ([]Code, error) {
+	if !g.wrapperConvertible(mf, srcType, dstType) {
+		return nil, nil
+	}
+	srcWrapper, dstWrapper := wrapperOf(srcType), wrapperOf(dstType)
+
+	// read the source value, with a condition if it may not be set
+	conds := []Code{}
+	value, valueType := srcExpr.Clone(), srcType
+	local := false
+	switch {
+	case srcWrapper != nil && srcWrapper.get != "":
+		v, ok := mf.localName("v"), mf.localName("ok")
+		conds = append(conds,
+			List(Id(v), Id(ok)).Op(":=").Add(srcExpr.Clone()).Dot(srcWrapper.get).Params(),
+			Id(ok),
+		)
+		value, valueType = Id(v), srcWrapper.valueType
+		local = true
+	case srcWrapper != nil:
+		if dstWrapper != nil || isPointer(dstType) {
+			conds = append(conds, srcExpr.Clone().Dot(validField))
+		}
+		value, valueType = srcExpr.Clone().Dot(srcWrapper.valueField), srcWrapper.valueType
+	case isPointer(srcType):
+		conds = append(conds, srcExpr.Clone().Op("!=").Nil())
+		value, valueType = Op("*").Add(srcExpr.Clone()), unwrapPointer(srcType)
+	}
+
+	assign := []Code{}
+	switch {
+	case dstWrapper != nil:
+		conv, err := g.convertSourceTo(mf, value, valueType, dstWrapper.valueType)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if dstWrapper.set != "" {
+			assign = append(assign, dstExpr.Clone().Dot(dstWrapper.set).Params(conv))
+			break
+		}
+		assign = append(assign, dstExpr.Clone().Op("=").Add(g.genType(dstType)).Values(Dict{
+			Id(dstWrapper.valueField): conv,
+			Id(validField):            True(),
+		}))
+	case isPointer(dstType):
+		elem := unwrapPointer(dstType)
+		if local && types.AssignableTo(valueType, elem) {
+			assign = append(assign, dstExpr.Clone().Op("=").Op("&").Add(value))
+			break
+		}
+		conv, err := g.convertSourceTo(mf, value, valueType, elem)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		p := mf.localName("p")
+		assign = append(assign,
+			Id(p).Op(":=").Add(conv),
+			dstExpr.Clone().Op("=").Op("&").Id(p),
+		)
+	default:
+		conv, err := g.convertSourceTo(mf, value, valueType, dstType)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		assign = append(assign, dstExpr.Clone().Op("=").Add(conv))
+	}
+
+	if len(conds) == 0 {
+		return assign, nil
+	}
+
+	stmt := If(conds...).Block(assign...)
+	if !mf.dstConstructed {
+		// reset values left from a previous mapping
+		stmt = stmt.Else().Block(dstExpr.Clone().Op("=").Add(g.zeroValue(dstType)))
+	}
+	return []Code{stmt}, nil
+}
+
+// zeroValue returns an expression for the zero value of ty.
+func (g *Generator) zeroValue(ty types.Type) *Statement {
+	switch u := ty.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Signature, *types.Chan:
+		return Nil()
+	case *types.Struct, *types.Array:
+		return g.genType(ty).Values()
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return False()
+		case u.Info()&types.IsString != 0:
+			return Lit("")
+		}
+		return Lit(0)
+	}
+	return Op("*").New(g.genType(ty))
+}
@@ -1,11 +1,17 @@
 module github.com/paultyng/go-typemapper
 
-go 1.12
+go 1.22.0
 
 require (
-	github.com/dave/jennifer v1.3.0
-	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dave/jennifer v1.7.1
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.3.0
-	golang.org/x/tools v0.0.0-20190514230902-921b34c7d07f
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 )
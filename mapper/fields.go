@@ -0,0 +1,170 @@
+package mapper
+
+import (
+	"go/types"
+	"strings"
+)
+
+// fieldSet is the fields of a struct selectable by name, including fields
+// promoted from embedded structs.
+type fieldSet struct {
+	// fields are in declaration order, with promoted fields following the
+	// field they are embedded in
+	fields []Field
+	// ambiguous are fields with names declared more than once at the
+	// shallowest depth they appear, which are not selectable
+	ambiguous []Field
+}
+
+// structFields returns the field set of st following the selector rules of
+// the Go spec: shallower fields hide deeper fields with the same name, and
+// names declared more than once at the same depth are ambiguous.
+func structFields(st *types.Struct) fieldSet {
+	all := []Field{}
+	var walk func(st *types.Struct, parents []Field, seen []*types.Struct)
+	walk = func(st *types.Struct, parents []Field, seen []*types.Struct) {
+		for i := 0; i < st.NumFields(); i++ {
+			v := st.Field(i)
+			f := fieldFromVar(v)
+			f.parents = parents
+			all = append(all, f)
+
+			if !v.Anonymous() {
+				continue
+			}
+			est := unwrapStruct(v.Type())
+			if est == nil || containsStruct(seen, est) {
+				continue
+			}
+			walk(est, append(append([]Field{}, parents...), f), append(seen, est))
+		}
+	}
+	walk(st, nil, []*types.Struct{st})
+
+	depths := map[string]int{}
+	counts := map[string]int{}
+	for _, f := range all {
+		d, ok := depths[f.key]
+		switch {
+		case !ok || f.depth() < d:
+			depths[f.key] = f.depth()
+			counts[f.key] = 1
+		case f.depth() == d:
+			counts[f.key]++
+		}
+	}
+
+	fs := fieldSet{}
+	for _, f := range all {
+		if f.depth() != depths[f.key] {
+			// hidden by a shallower field
+			continue
+		}
+		if counts[f.key] > 1 {
+			fs.ambiguous = append(fs.ambiguous, f)
+			continue
+		}
+		fs.fields = append(fs.fields, f)
+	}
+	return fs
+}
+
+// lookup returns the selectable field with the name.
+func (fs fieldSet) lookup(name string) (Field, bool) {
+	for _, f := range fs.fields {
+		if f.key == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// lookupPath returns the field selected by a dotted path of names, starting
+// in the field set fs.
+func lookupPath(fs fieldSet, path string) (Field, bool) {
+	names := strings.Split(path, ".")
+	f, ok := fs.lookup(names[0])
+	for _, name := range names[1:] {
+		if !ok {
+			break
+		}
+		st := unwrapStruct(f.Type())
+		if st == nil {
+			return Field{}, false
+		}
+		f, ok = structFields(st).withParent(f).lookup(name)
+	}
+	return f, ok
+}
+
+// withParent returns the field set with the fields nested in parent.
+func (fs fieldSet) withParent(parent Field) fieldSet {
+	parents := append(append([]Field{}, parent.parents...), parent)
+	nest := func(fields []Field) []Field {
+		nested := make([]Field, 0, len(fields))
+		for _, f := range fields {
+			f.parents = append(append([]Field{}, parents...), f.parents...)
+			nested = append(nested, f)
+		}
+		return nested
+	}
+	return fieldSet{
+		fields:    nest(fs.fields),
+		ambiguous: nest(fs.ambiguous),
+	}
+}
+
+// parent returns the field selected before the field, or nil.
+func (f *Field) parent() *Field {
+	if len(f.parents) == 0 {
+		return nil
+	}
+	return &f.parents[len(f.parents)-1]
+}
+
+// isAmbiguous reports if the name is declared more than once at the same
+// depth.
+func (fs fieldSet) isAmbiguous(name string) bool {
+	for _, f := range fs.ambiguous {
+		if f.key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPromoted reports if any fields are promoted through the embedded field e.
+func (fs fieldSet) hasPromoted(e Field) bool {
+	for _, f := range append(append([]Field{}, fs.fields...), fs.ambiguous...) {
+		if f.promotedFrom(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// promotedFrom reports if f is promoted through the embedded field e.
+func (f *Field) promotedFrom(e Field) bool {
+	if len(f.parents) <= len(e.parents) {
+		return false
+	}
+	for i, ef := range e.parents {
+		if f.parents[i].v != ef.v {
+			return false
+		}
+	}
+	return f.parents[len(e.parents)].v == e.v
+}
+
+func (f *Field) depth() int {
+	return len(f.parents)
+}
+
+func containsStruct(sts []*types.Struct, st *types.Struct) bool {
+	for _, s := range sts {
+		if s == st {
+			return true
+		}
+	}
+	return false
+}
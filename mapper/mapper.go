@@ -2,41 +2,108 @@ package mapper
 
 import (
 	"go/types"
+	"regexp"
 	"strings"
 )
 
 type StructMapper struct {
 	prefixes  []string
 	ignore    []string
-	manualMap map[string]string
+	only      []string
+	manualMap map[string]sourceField
 
-	src *types.Struct
-	dst *types.Struct
+	// ignoreSrcs are source fields not matched or reported as unused, and
+	// used are source fields consumed by values computed by the caller
+	ignoreSrcs []sourceField
+	used       []sourceField
+
+	// ignorePatterns and ignoreTypes apply to both source and destination
+	// fields
+	ignorePatterns []*regexp.Regexp
+	ignoreTypes    []types.Type
+
+	convertible func(src, dst types.Type) bool
+
+	// pkg is the package the mapping is generated in, fields of other
+	// packages are only accessible if exported
+	pkg *types.Package
+
+	// srcs are searched in order, the first is the primary source
+	srcs []*types.Struct
+	dst  *types.Struct
+}
+
+type sourceField struct {
+	source int
+	name   string
+	// value is set for values computed by the caller
+	value *Field
 }
 
 func NewStructMapper(src, dst types.Type) *StructMapper {
 	m := &StructMapper{}
 
-	m.src = unwrapStruct(src)
+	srcStruct := unwrapStruct(src)
 	m.dst = unwrapStruct(dst)
 
-	if m.src == nil || m.dst == nil {
+	if srcStruct == nil || m.dst == nil {
 		return nil
 	}
+	m.srcs = []*types.Struct{srcStruct}
 
 	return m
 }
 
+// AddSource adds another source struct, searched for matching fields after
+// the sources already added. It returns nil if src is not a struct.
+func (m *StructMapper) AddSource(src types.Type) *StructMapper {
+	srcStruct := unwrapStruct(src)
+	if srcStruct == nil {
+		return nil
+	}
+	m.srcs = append(m.srcs, srcStruct)
+	return m
+}
+
 func (m *StructMapper) RecognizePrefixes(prefixes ...string) *StructMapper {
 	m.prefixes = prefixes
 	return m
 }
 
+// MapField maps the destination field from the first source with a field
+// named srcField. Both fields can be dotted paths to nested fields.
 func (m *StructMapper) MapField(srcField, dstField string) *StructMapper {
+	for i, src := range m.srcs {
+		if _, ok := lookupPath(structFields(src), srcField); ok {
+			return m.MapSourceField(i, srcField, dstField)
+		}
+	}
+	return m.MapSourceField(0, srcField, dstField)
+}
+
+// MapSourceField maps the destination field from a field of a specific
+// source, by index in the order sources were added.
+func (m *StructMapper) MapSourceField(source int, srcField, dstField string) *StructMapper {
+	if m.manualMap == nil {
+		m.manualMap = map[string]sourceField{}
+	}
+	m.manualMap[dstField] = sourceField{
+		source: source,
+		name:   srcField,
+	}
+	return m
+}
+
+// MapValue maps the destination field from a value of type ty computed by
+// the caller, for example a method call, the pair has Value set and a source
+// field named key.
+func (m *StructMapper) MapValue(key string, ty types.Type, dstField string) *StructMapper {
 	if m.manualMap == nil {
-		m.manualMap = map[string]string{}
+		m.manualMap = map[string]sourceField{}
+	}
+	m.manualMap[dstField] = sourceField{
+		value: &Field{key: key, ty: ty},
 	}
-	m.manualMap[dstField] = srcField
 	return m
 }
 
@@ -45,7 +112,65 @@ func (m *StructMapper) IgnoreFields(dstFields ...string) *StructMapper {
 	return m
 }
 
+// IgnoreSourceFields stops fields of a source, by index in the order sources
+// were added, from being matched or reported as unused.
+func (m *StructMapper) IgnoreSourceFields(source int, srcFields ...string) *StructMapper {
+	for _, f := range srcFields {
+		m.ignoreSrcs = append(m.ignoreSrcs, sourceField{source: source, name: f})
+	}
+	return m
+}
+
+// UseSourceFields marks fields of a source as consumed, for example by a
+// value mapped with MapValue, so they are not reported as unused.
+func (m *StructMapper) UseSourceFields(source int, srcFields ...string) *StructMapper {
+	for _, f := range srcFields {
+		m.used = append(m.used, sourceField{source: source, name: f})
+	}
+	return m
+}
+
+// FromPackage sets the package the mapping is generated in. Unexported
+// fields of other packages are not matched, destination fields are reported
+// as inaccessible.
+func (m *StructMapper) FromPackage(pkg *types.Package) *StructMapper {
+	m.pkg = pkg
+	return m
+}
+
+// IgnoreFieldsMatching ignores destination fields with names matching any of
+// the patterns, and does not match source fields with those names.
+func (m *StructMapper) IgnoreFieldsMatching(patterns ...*regexp.Regexp) *StructMapper {
+	m.ignorePatterns = append(m.ignorePatterns, patterns...)
+	return m
+}
+
+// IgnoreFieldsOfType ignores destination fields of any of the types, and
+// does not match source fields of those types.
+func (m *StructMapper) IgnoreFieldsOfType(tys ...types.Type) *StructMapper {
+	m.ignoreTypes = append(m.ignoreTypes, tys...)
+	return m
+}
+
+// OnlyFields limits the mapping to the destination fields, and fields
+// mapped with MapField, all other destination fields are ignored.
+func (m *StructMapper) OnlyFields(dstFields ...string) *StructMapper {
+	m.only = append(m.only, dstFields...)
+	return m
+}
+
+// ConvertTypes registers a check for field types that are not assignable
+// but that the caller knows how to convert, for example with a mapping function.
+func (m *StructMapper) ConvertTypes(convertible func(src, dst types.Type) bool) *StructMapper {
+	m.convertible = convertible
+	return m
+}
+
 func (m *StructMapper) typesMappable(src, dst types.Type) bool {
+	if m.convertible != nil && m.convertible(src, dst) {
+		return true
+	}
+
 	var unwrap func(types.Type) types.Type
 	unwrap = func(t types.Type) types.Type {
 		switch t := t.(type) {
@@ -64,6 +189,12 @@ func (m *StructMapper) typesMappable(src, dst types.Type) bool {
 }
 
 func (m *StructMapper) fieldsMappable(src, dst *types.Var) bool {
+	return m.namesMatch(src, dst) && m.typesMappable(src.Type(), dst.Type())
+}
+
+// namesMatch reports if the field names are equal, ignoring recognized
+// prefixes.
+func (m *StructMapper) namesMatch(src, dst *types.Var) bool {
 	srcNames := []string{src.Name()}
 	dstNames := []string{dst.Name()}
 
@@ -81,86 +212,465 @@ func (m *StructMapper) fieldsMappable(src, dst *types.Var) bool {
 
 	// TODO: add options for this, casing, type conversions, etc
 
-	matchFound := func() bool {
-		for _, dstName := range dstNames {
-			if dstName == "" {
+	for _, dstName := range dstNames {
+		if dstName == "" {
+			continue
+		}
+
+		for _, srcName := range srcNames {
+			if srcName == "" {
 				continue
 			}
-
-			for _, srcName := range srcNames {
-				if srcName == "" {
-					continue
-				}
-				if dstName == srcName {
-					return true
-				}
+			if dstName == srcName {
+				return true
 			}
 		}
-		return false
-	}()
-
-	if !matchFound {
-		return false
 	}
+	return false
+}
 
-	return m.typesMappable(src.Type(), dst.Type())
+// mismatch returns the first selectable field of src, the source with index
+// source, with a name matching dstField but a type that is not mappable.
+func (m *StructMapper) mismatch(src fieldSet, source int, dstField *types.Var) (Field, bool) {
+	for _, srcField := range src.fields {
+		if m.ignoredField(srcField) || m.ignoredSource(source, srcField) || !m.accessible(srcField) {
+			continue
+		}
+		if m.namesMatch(srcField.v, dstField) {
+			return srcField, true
+		}
+	}
+	return Field{}, false
 }
 
-func (m *StructMapper) findPair(src, dst *types.Struct, dstField *types.Var) *types.Var {
-	for i := 0; i < src.NumFields(); i++ {
-		srcField := src.Field(i)
-		if m.fieldsMappable(srcField, dstField) {
-			return srcField
+// candidates returns the selectable fields of src, the source with index
+// source, matching dstField. Fields with the same name as dstField are
+// preferred to fields matching with a prefix.
+func (m *StructMapper) candidates(src fieldSet, source int, dstField *types.Var) []Field {
+	exact, prefixed := []Field{}, []Field{}
+	for _, srcField := range src.fields {
+		if m.ignoredField(srcField) || m.ignoredSource(source, srcField) || !m.accessible(srcField) {
+			continue
+		}
+		if !m.fieldsMappable(srcField.v, dstField) {
+			continue
+		}
+		if srcField.key == dstField.Name() {
+			exact = append(exact, srcField)
+			continue
 		}
+		prefixed = append(prefixed, srcField)
 	}
-	return nil
+	if len(exact) > 0 {
+		return exact
+	}
+	return prefixed
 }
 
+// Map matches the destination fields to source fields. Destination struct
+// fields with nested fields mapped or ignored are not assigned as a whole,
+// their fields are matched instead, first from the source field with the
+// same name, then from the sources.
 func (m *StructMapper) Map() MapConfiguration {
 	noMatch := []Field{}
+	ambiguous := []Field{}
+	ignored := []Field{}
+	inaccessible := []Field{}
+	conflicts := []Conflict{}
+	mismatches := []TypeMismatch{}
 	pairs := []FieldPair{}
 
-	for i := 0; i < m.dst.NumFields(); i++ {
-		dstField := m.dst.Field(i)
+	srcFields := make([]fieldSet, 0, len(m.srcs))
+	for _, src := range m.srcs {
+		srcFields = append(srcFields, structFields(src))
+	}
+	dstFields := structFields(m.dst)
 
-		if dstField.Name() == "_" {
-			continue
+	// embedded fields mapped or ignored as a whole, their promoted fields
+	// are skipped
+	skipped := []Field{}
+	isSkipped := func(f Field) bool {
+		for _, s := range skipped {
+			if f.promotedFrom(s) {
+				return true
+			}
 		}
+		return false
+	}
 
-		ignoreDst := false
-		for _, ig := range m.ignore {
-			if dstField.Name() == ig {
-				ignoreDst = true
-				break
+	// source fields matching expanded destination fields, by path
+	type nestedSource struct {
+		fields fieldSet
+		source int
+	}
+	nestedSrcs := map[string]nestedSource{}
+	// sourcesFor returns the field sets to search for the destination field,
+	// with the index of their source, in order
+	sourcesFor := func(dstField Field) ([]fieldSet, []int) {
+		sets, indexes := []fieldSet{}, []int{}
+		if parent := dstField.parent(); parent != nil {
+			if nested, ok := nestedSrcs[parent.Path()]; ok {
+				sets, indexes = append(sets, nested.fields), append(indexes, nested.source)
 			}
 		}
-		if ignoreDst {
+		for j, src := range srcFields {
+			sets, indexes = append(sets, src), append(indexes, j)
+		}
+		return sets, indexes
+	}
+	// mismatchFor returns the first source field matching the destination
+	// field by name but not by type
+	mismatchFor := func(dstField Field) (TypeMismatch, bool) {
+		sets, indexes := sourcesFor(dstField)
+		for j := range sets {
+			if srcField, ok := m.mismatch(sets[j], indexes[j], dstField.v); ok {
+				return TypeMismatch{
+					Source:      srcField,
+					SourceIndex: indexes[j],
+					Destination: dstField,
+				}, true
+			}
+		}
+		return TypeMismatch{}, false
+	}
+
+	queue := append([]Field{}, dstFields.fields...)
+	for i := 0; i < len(queue); i++ {
+		dstField := queue[i]
+		if dstField.Name() == "_" || isSkipped(dstField) {
 			continue
 		}
 
-		var srcField *types.Var
-		if mm, ok := m.manualMap[dstField.Name()]; ok {
-			for i := 0; i < m.src.NumFields(); i++ {
-				f := m.src.Field(i)
-				if f.Name() == mm {
-					srcField = f
+		if !m.accessible(dstField) {
+			inaccessible = append(inaccessible, dstField)
+			skipped = append(skipped, dstField)
+			continue
+		}
+		if m.ignored(dstField) || m.excluded(dstFields, dstField) {
+			ignored = append(ignored, dstField)
+			skipped = append(skipped, dstField)
+			continue
+		}
+		if len(m.only) > 0 && dstField.v.Anonymous() && !m.listed(dstField) {
+			// map the listed promoted fields instead
+			continue
+		}
+
+		var (
+			srcField Field
+			source   int
+			found    bool
+		)
+		mm, manual := m.manualFor(dstField)
+		switch {
+		case manual && mm.value != nil:
+			srcField, found = *mm.value, true
+		case manual:
+			if mm.source < len(srcFields) {
+				srcField, found = lookupPath(srcFields[mm.source], mm.name)
+				source = mm.source
+			}
+		case m.hasNested(dstField):
+			if dstField.v.Anonymous() {
+				// promoted fields are already in the queue
+				continue
+			}
+			st := unwrapStruct(dstField.Type())
+			if st == nil {
+				break
+			}
+			children := structFields(st).withParent(dstField)
+			queue = append(queue[:i+1], append(children.fields, queue[i+1:]...)...)
+			for j, src := range srcFields {
+				if f, ok := src.lookup(dstField.Name()); ok && m.accessible(f) && unwrapStruct(f.Type()) != nil {
+					nestedSrcs[dstField.Path()] = nestedSource{
+						fields: structFields(unwrapStruct(f.Type())).withParent(f),
+						source: j,
+					}
 					break
 				}
 			}
-		} else {
-			srcField = m.findPair(m.src, m.dst, dstField)
+			continue
+		default:
+			var candidates []Field
+			sets, indexes := sourcesFor(dstField)
+			for j := 0; len(candidates) == 0 && j < len(sets); j++ {
+				candidates = m.candidates(sets[j], indexes[j], dstField.v)
+				source = indexes[j]
+			}
+			if len(candidates) > 1 {
+				conflicts = append(conflicts, Conflict{
+					Destination: dstField,
+					SourceIndex: source,
+					Candidates:  candidates,
+				})
+				continue
+			}
+			if len(candidates) == 1 {
+				srcField, found = candidates[0], true
+			}
 		}
-		if srcField == nil {
-			noMatch = append(noMatch, fieldFromVar(dstField))
+		if !found {
+			switch {
+			case dstField.v.Anonymous() && dstFields.hasPromoted(dstField):
+				// map the promoted fields instead
+			case !manual && m.ambiguousSource(srcFields, dstField.Name()):
+				ambiguous = append(ambiguous, dstField)
+			default:
+				if !manual {
+					if tm, ok := mismatchFor(dstField); ok {
+						mismatches = append(mismatches, tm)
+						break
+					}
+				}
+				noMatch = append(noMatch, dstField)
+			}
 			continue
 		}
+		if dstField.v.Anonymous() {
+			skipped = append(skipped, dstField)
+		}
 		pairs = append(pairs, FieldPair{
-			Source:      fieldFromVar(srcField),
-			Destination: fieldFromVar(dstField),
+			Source:      srcField,
+			SourceIndex: source,
+			Destination: dstField,
+			Value:       manual && mm.value != nil,
 		})
 	}
+	for _, dstField := range dstFields.ambiguous {
+		if isSkipped(dstField) {
+			continue
+		}
+		if !m.accessible(dstField) {
+			inaccessible = append(inaccessible, dstField)
+			continue
+		}
+		if m.ignored(dstField) || m.excluded(dstFields, dstField) {
+			ignored = append(ignored, dstField)
+			continue
+		}
+		ambiguous = append(ambiguous, dstField)
+	}
+	unused := m.unused(srcFields, pairs)
 	return MapConfiguration{
-		Pairs:   pairs,
-		NoMatch: noMatch,
+		Pairs:        pairs,
+		NoMatch:      noMatch,
+		Ambiguous:    ambiguous,
+		Ignored:      ignored,
+		Inaccessible: inaccessible,
+		Conflicts:    conflicts,
+		TypeMismatch: mismatches,
+		Unused:       unused,
+		Suggestions:  m.suggest(noMatch, unused),
+	}
+}
+
+// unused returns the selectable source fields not consumed by any pair, not
+// ignored and not marked as used. Struct fields with only some nested fields
+// consumed are not used as a whole, their other nested fields are returned.
+func (m *StructMapper) unused(srcFields []fieldSet, pairs []FieldPair) []UnusedField {
+	consumed := append([]sourceField{}, m.used...)
+	for _, p := range pairs {
+		if !p.Value {
+			consumed = append(consumed, sourceField{source: p.SourceIndex, name: p.Source.Path()})
+		}
+	}
+
+	unused := []UnusedField{}
+	for j, src := range srcFields {
+		unused = m.unusedFields(unused, j, src, consumed)
+	}
+	return unused
+}
+
+// unusedFields appends the fields of fs, in the source with index source,
+// that are not consumed to unused.
+func (m *StructMapper) unusedFields(unused []UnusedField, source int, fs fieldSet, consumed []sourceField) []UnusedField {
+	for _, f := range fs.fields {
+		switch {
+		case f.Name() == "_",
+			f.v.Anonymous() && fs.hasPromoted(f),
+			m.ignoredField(f),
+			m.ignoredSource(source, f),
+			!m.accessible(f),
+			selects(source, f, consumed):
+			continue
+		case selectsNested(source, f, consumed):
+			if st := unwrapStruct(f.Type()); st != nil {
+				unused = m.unusedFields(unused, source, structFields(st).withParent(f), consumed)
+				continue
+			}
+		}
+		unused = append(unused, UnusedField{
+			Source:      f,
+			SourceIndex: source,
+		})
+	}
+	return unused
+}
+
+// ignoredSource reports if the field of the source with index source, or
+// a field selected before it, is ignored with IgnoreSourceFields.
+func (m *StructMapper) ignoredSource(source int, f Field) bool {
+	for _, sel := range f.selectors() {
+		for _, ig := range m.ignoreSrcs {
+			if ig.source == source && (sel == ig.name || strings.HasPrefix(sel, ig.name+".")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selects reports if any of the fields of the source with index source is f
+// or has f nested in it.
+func selects(source int, f Field, fields []sourceField) bool {
+	path := f.Path()
+	for _, sf := range fields {
+		if sf.source == source && (sf.name == path || strings.HasPrefix(path, sf.name+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectsNested reports if any of the fields of the source with index source
+// is nested in f.
+func selectsNested(source int, f Field, fields []sourceField) bool {
+	path := f.Path()
+	for _, sf := range fields {
+		if sf.source == source && strings.HasPrefix(sf.name, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// selectors returns the names the field can be referred to by in MapField
+// and IgnoreFields, its path and its name when promoted.
+func (f *Field) selectors() []string {
+	selectors := []string{f.Path()}
+	for _, p := range f.parents {
+		if !p.v.Anonymous() {
+			return selectors
+		}
+	}
+	if len(f.parents) > 0 {
+		selectors = append(selectors, f.key)
+	}
+	return selectors
+}
+
+func (m *StructMapper) manualFor(dstField Field) (sourceField, bool) {
+	for _, sel := range dstField.selectors() {
+		if mm, ok := m.manualMap[sel]; ok {
+			return mm, true
+		}
+	}
+	return sourceField{}, false
+}
+
+func (m *StructMapper) ignored(dstField Field) bool {
+	if m.ignoredField(dstField) {
+		return true
+	}
+	for _, sel := range dstField.selectors() {
+		for _, ig := range m.ignore {
+			if sel == ig {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// accessible reports if the field, and each field selected before it, is
+// exported or declared in the package the mapping is generated in.
+func (m *StructMapper) accessible(f Field) bool {
+	if m.pkg == nil {
+		return true
+	}
+	for _, sel := range append(append([]Field{}, f.parents...), f) {
+		if sel.v != nil && !sel.v.Exported() && sel.v.Pkg() != m.pkg {
+			return false
+		}
+	}
+	return true
+}
+
+// ignoredField reports if the field name matches a pattern or its type is
+// ignored.
+func (m *StructMapper) ignoredField(f Field) bool {
+	for _, re := range m.ignorePatterns {
+		if re.MatchString(f.key) {
+			return true
+		}
+	}
+	for _, ty := range m.ignoreTypes {
+		if types.Identical(f.ty, ty) {
+			return true
+		}
+	}
+	return false
+}
+
+// excluded reports if the destination field is left out by OnlyFields,
+// it is neither listed nor selected before or promoted to a listed field.
+func (m *StructMapper) excluded(dstFields fieldSet, dstField Field) bool {
+	if len(m.only) == 0 || m.listed(dstField) || m.hasNested(dstField) {
+		return false
+	}
+	for _, f := range dstFields.fields {
+		if f.promotedFrom(dstField) && m.listed(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// listed reports if the destination field is listed in OnlyFields or
+// mapped with MapField.
+func (m *StructMapper) listed(dstField Field) bool {
+	if _, ok := m.manualFor(dstField); ok {
+		return true
+	}
+	for _, sel := range dstField.selectors() {
+		for _, only := range m.only {
+			if sel == only {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasNested reports if fields nested in the destination field are mapped,
+// ignored or listed in OnlyFields.
+func (m *StructMapper) hasNested(dstField Field) bool {
+	prefix := dstField.Path() + "."
+	for dst := range m.manualMap {
+		if strings.HasPrefix(dst, prefix) {
+			return true
+		}
+	}
+	for _, sel := range append(append([]string{}, m.ignore...), m.only...) {
+		if strings.HasPrefix(sel, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousSource reports if the first source declaring name declares it
+// more than once at the same depth.
+func (m *StructMapper) ambiguousSource(srcs []fieldSet, name string) bool {
+	for _, src := range srcs {
+		if _, ok := src.lookup(name); ok {
+			return false
+		}
+		if src.isAmbiguous(name) {
+			return true
+		}
 	}
+	return false
 }
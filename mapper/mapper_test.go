@@ -3,6 +3,7 @@ package mapper
 import (
 	"fmt"
 	"go/types"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,7 +40,7 @@ func TestFindMatchingField(t *testing.T) {
 		{nil, types.NewStruct([]*types.Var{fooIntVar}, nil), types.NewStruct([]*types.Var{fooStringVar}, nil), fooStringVar},
 
 		{fooStringVar, types.NewStruct([]*types.Var{fooStringVar}, nil), types.NewStruct([]*types.Var{fooStringVar}, nil), fooStringVar},
-		{fooStringVar, types.NewStruct([]*types.Var{fooStringVar}, nil), types.NewStruct([]*types.Var{fooPtrStringVar}, nil), fooStringVar},
+		{fooStringVar, types.NewStruct([]*types.Var{fooStringVar}, nil), types.NewStruct([]*types.Var{fooPtrStringVar}, nil), fooPtrStringVar},
 		{fooStringVar, types.NewStruct([]*types.Var{fooStringVar, barIntVar}, nil), types.NewStruct([]*types.Var{fooStringVar}, nil), fooStringVar},
 		{fooStringVar, types.NewStruct([]*types.Var{fooStringVar, barIntVar}, nil), types.NewStruct([]*types.Var{fooStringVar, barIntVar}, nil), fooStringVar},
 
@@ -52,10 +53,351 @@ func TestFindMatchingField(t *testing.T) {
 			sm.RecognizePrefixes("Get")
 			sm.MapField("MapFieldSrc", "MapFieldDst")
 
-			actual := sm.findPair(c.src, c.dst, c.dstField)
+			var actual *types.Var
+			for _, p := range sm.Map().Pairs {
+				if p.Destination.v == c.dstField {
+					actual = p.Source.v
+				}
+			}
 			assert.Equal(t, c.expected, actual)
 		})
 	}
 }
 
+func TestMapAddSource(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		fooVar     = types.NewVar(1, pkg, "Foo", stringType)
+		barVar     = types.NewVar(2, pkg, "Bar", stringType)
+		bazVar     = types.NewVar(3, pkg, "Baz", stringType)
+		src2FooVar = types.NewVar(4, pkg, "Foo", stringType)
+	)
+
+	src := types.NewStruct([]*types.Var{fooVar}, nil)
+	src2 := types.NewStruct([]*types.Var{src2FooVar, barVar}, nil)
+	dst := types.NewStruct([]*types.Var{fooVar, barVar, bazVar}, nil)
+
+	sm := NewStructMapper(src, dst).AddSource(src2)
+	actual := sm.Map()
+
+	assert.Equal(t, []FieldPair{
+		{Source: fieldFromVar(fooVar), SourceIndex: 0, Destination: fieldFromVar(fooVar)},
+		{Source: fieldFromVar(barVar), SourceIndex: 1, Destination: fieldFromVar(barVar)},
+	}, actual.Pairs)
+	assert.Equal(t, []Field{fieldFromVar(bazVar)}, actual.NoMatch)
+}
+
 // TODO: test IgnoreFields
+
+func TestMapEmbedded(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	named := func(name string, fields ...*types.Var) *types.Named {
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), types.NewStruct(fields, nil), nil)
+	}
+
+	var (
+		idVar    = types.NewField(1, pkg, "ID", stringType, false)
+		aNameVar = types.NewField(2, pkg, "Name", stringType, false)
+		bNameVar = types.NewField(3, pkg, "Name", stringType, false)
+
+		baseVar = types.NewField(4, pkg, "Base", named("Base", idVar), true)
+		aVar    = types.NewField(5, pkg, "A", types.NewPointer(named("A", aNameVar)), true)
+		bVar    = types.NewField(6, pkg, "B", named("B", bNameVar), true)
+
+		dstIDVar   = types.NewField(7, pkg, "ID", stringType, false)
+		dstNameVar = types.NewField(8, pkg, "Name", stringType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{baseVar, aVar, bVar}, nil)
+	dst := types.NewStruct([]*types.Var{dstIDVar, dstNameVar}, nil)
+
+	actual := NewStructMapper(src, dst).Map()
+
+	assert.Len(t, actual.Pairs, 1)
+	assert.Equal(t, "ID", actual.Pairs[0].Source.Name())
+	assert.Equal(t, []Field{fieldFromVar(baseVar)}, actual.Pairs[0].Source.Parents())
+	assert.Empty(t, actual.NoMatch)
+	assert.Equal(t, []Field{fieldFromVar(dstNameVar)}, actual.Ambiguous)
+
+	// embedded destination fields are mapped as a whole when possible
+	actual = NewStructMapper(src, types.NewStruct([]*types.Var{baseVar}, nil)).Map()
+	assert.Equal(t, []FieldPair{
+		{Source: fieldFromVar(baseVar), Destination: fieldFromVar(baseVar)},
+	}, actual.Pairs)
+}
+
+func TestMapNestedPaths(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		cityVar    = types.NewField(1, pkg, "City", stringType, false)
+		streetVar  = types.NewField(2, pkg, "Street", stringType, false)
+		addressVar = types.NewField(3, pkg, "Address", types.NewPointer(types.NewStruct([]*types.Var{cityVar, streetVar}, nil)), false)
+
+		dstCityVar    = types.NewField(4, pkg, "City", stringType, false)
+		dstStreetVar  = types.NewField(5, pkg, "Street", stringType, false)
+		dstAddressVar = types.NewField(6, pkg, "Address", types.NewStruct([]*types.Var{dstCityVar, dstStreetVar}, nil), false)
+		townVar       = types.NewField(7, pkg, "Town", stringType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{addressVar}, nil)
+	dst := types.NewStruct([]*types.Var{townVar, dstAddressVar}, nil)
+
+	actual := NewStructMapper(src, dst).
+		MapField("Address.City", "Town").
+		IgnoreFields("Address.City").
+		Map()
+
+	paths := []string{}
+	for _, p := range actual.Pairs {
+		paths = append(paths, fmt.Sprintf("%s <- %s", p.Destination.Path(), p.Source.Path()))
+	}
+	assert.Equal(t, []string{
+		"Town <- Address.City",
+		"Address.Street <- Address.Street",
+	}, paths)
+	assert.Empty(t, actual.NoMatch)
+}
+
+func TestMapOnlyFields(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		idVar    = types.NewField(1, pkg, "ID", stringType, false)
+		nameVar  = types.NewField(2, pkg, "Name", stringType, false)
+		notesVar = types.NewField(3, pkg, "Notes", stringType, false)
+		cityVar  = types.NewField(4, pkg, "City", stringType, false)
+
+		streetVar  = types.NewField(5, pkg, "Street", stringType, false)
+		addressVar = types.NewField(6, pkg, "Address", types.NewStruct([]*types.Var{cityVar, streetVar}, nil), false)
+		baseVar    = types.NewField(7, pkg, "Base", types.NewStruct([]*types.Var{idVar}, nil), true)
+	)
+
+	src := types.NewStruct([]*types.Var{idVar, nameVar, notesVar, cityVar}, nil)
+	dst := types.NewStruct([]*types.Var{baseVar, nameVar, notesVar, addressVar}, nil)
+
+	actual := NewStructMapper(src, dst).
+		OnlyFields("ID", "Address.City").
+		MapField("Name", "Name").
+		Map()
+
+	paths := []string{}
+	for _, p := range actual.Pairs {
+		paths = append(paths, fmt.Sprintf("%s <- %s", p.Destination.Path(), p.Source.Path()))
+	}
+	assert.Equal(t, []string{
+		"Base.ID <- ID",
+		"Name <- Name",
+		"Address.City <- City",
+	}, paths)
+
+	ignored := []string{}
+	for _, f := range actual.Ignored {
+		ignored = append(ignored, f.Path())
+	}
+	assert.Equal(t, []string{"Notes", "Address.Street"}, ignored)
+	assert.Empty(t, actual.NoMatch)
+}
+
+func TestMapIgnoreFieldsMatching(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	var (
+		stringType = types.Universe.Lookup("string").Type()
+		intType    = types.Universe.Lookup("int").Type()
+	)
+
+	var (
+		xxxIDVar  = types.NewField(1, pkg, "XXX_ID", stringType, false)
+		nameVar   = types.NewField(2, pkg, "Name", stringType, false)
+		sizeVar   = types.NewField(3, pkg, "XXX_size", intType, false)
+		idVar     = types.NewField(4, pkg, "ID", stringType, false)
+		countVar  = types.NewField(5, pkg, "Count", intType, false)
+		seqVar    = types.NewField(6, pkg, "seq", intType, false)
+		dstSeqVar = types.NewField(7, pkg, "seq", intType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{xxxIDVar, nameVar, seqVar}, nil)
+	dst := types.NewStruct([]*types.Var{idVar, nameVar, sizeVar, countVar, dstSeqVar}, nil)
+
+	actual := NewStructMapper(src, dst).
+		RecognizePrefixes("XXX_").
+		IgnoreFieldsMatching(regexp.MustCompile("^XXX_")).
+		IgnoreFieldsOfType(intType).
+		Map()
+
+	assert.Equal(t, []FieldPair{
+		{Source: fieldFromVar(nameVar), Destination: fieldFromVar(nameVar)},
+	}, actual.Pairs)
+
+	noMatch := []string{}
+	for _, f := range actual.NoMatch {
+		noMatch = append(noMatch, f.Path())
+	}
+	// the source XXX_ID is ignored, so ID is not matched through the prefix
+	assert.Equal(t, []string{"ID"}, noMatch)
+
+	ignored := []string{}
+	for _, f := range actual.Ignored {
+		ignored = append(ignored, f.Path())
+	}
+	assert.Equal(t, []string{"XXX_size", "Count", "seq"}, ignored)
+}
+
+func TestMapInaccessible(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+	otherPkg := types.NewPackage("example.com/other", "other")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		nameVar  = types.NewField(1, pkg, "Name", stringType, false)
+		stateVar = types.NewField(2, pkg, "state", stringType, false)
+
+		dstNameVar  = types.NewField(3, otherPkg, "Name", stringType, false)
+		dstStateVar = types.NewField(4, otherPkg, "state", stringType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{nameVar, stateVar}, nil)
+	dst := types.NewStruct([]*types.Var{dstNameVar, dstStateVar}, nil)
+
+	actual := NewStructMapper(src, dst).FromPackage(pkg).Map()
+
+	assert.Equal(t, []FieldPair{
+		{Source: fieldFromVar(nameVar), Destination: fieldFromVar(dstNameVar)},
+	}, actual.Pairs)
+	assert.Empty(t, actual.NoMatch)
+	assert.Equal(t, []Field{fieldFromVar(dstStateVar)}, actual.Inaccessible)
+
+	// the state of the other package can not be read either
+	actual = NewStructMapper(dst, src).FromPackage(pkg).Map()
+
+	assert.Equal(t, []Field{fieldFromVar(stateVar)}, actual.NoMatch)
+	assert.Empty(t, actual.Inaccessible)
+}
+
+func TestMapConflicts(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		serviceNameVar = types.NewField(1, pkg, "ServiceName", stringType, false)
+		getNameVar     = types.NewField(2, pkg, "GetName", stringType, false)
+		nameVar        = types.NewField(3, pkg, "Name", stringType, false)
+	)
+
+	dst := types.NewStruct([]*types.Var{nameVar}, nil)
+
+	// an exact match wins over prefixed matches
+	src := types.NewStruct([]*types.Var{serviceNameVar, getNameVar, nameVar}, nil)
+	actual := NewStructMapper(src, dst).RecognizePrefixes("Service", "Get").Map()
+	assert.Equal(t, []FieldPair{
+		{Source: fieldFromVar(nameVar), Destination: fieldFromVar(nameVar)},
+	}, actual.Pairs)
+	assert.Empty(t, actual.Conflicts)
+
+	src = types.NewStruct([]*types.Var{serviceNameVar, getNameVar}, nil)
+	actual = NewStructMapper(src, dst).RecognizePrefixes("Service", "Get").Map()
+	assert.Empty(t, actual.Pairs)
+	assert.Empty(t, actual.NoMatch)
+	assert.Equal(t, []Conflict{{
+		Destination: fieldFromVar(nameVar),
+		Candidates:  []Field{fieldFromVar(serviceNameVar), fieldFromVar(getNameVar)},
+	}}, actual.Conflicts)
+}
+
+func TestMapUnused(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		nameVar  = types.NewField(1, pkg, "Name", stringType, false)
+		notesVar = types.NewField(2, pkg, "Notes", stringType, false)
+		tagVar   = types.NewField(3, pkg, "Tag", stringType, false)
+		firstVar = types.NewField(4, pkg, "First", stringType, false)
+		extraVar = types.NewField(5, pkg, "Extra", stringType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{nameVar, notesVar, tagVar, firstVar}, nil)
+	dst := types.NewStruct([]*types.Var{nameVar}, nil)
+	other := types.NewStruct([]*types.Var{extraVar}, nil)
+
+	actual := NewStructMapper(src, dst).
+		AddSource(other).
+		IgnoreSourceFields(0, "Tag").
+		UseSourceFields(0, "First").
+		Map()
+
+	assert.Equal(t, []UnusedField{
+		{Source: fieldFromVar(notesVar), SourceIndex: 0},
+		{Source: fieldFromVar(extraVar), SourceIndex: 1},
+	}, actual.Unused)
+}
+
+func TestMapUnusedNested(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	stringType := types.Universe.Lookup("string").Type()
+
+	var (
+		cityVar    = types.NewField(1, pkg, "City", stringType, false)
+		streetVar  = types.NewField(2, pkg, "Street", stringType, false)
+		addressVar = types.NewField(3, pkg, "Address", types.NewStruct([]*types.Var{cityVar, streetVar}, nil), false)
+		townVar    = types.NewField(4, pkg, "Town", stringType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{addressVar}, nil)
+	dst := types.NewStruct([]*types.Var{townVar}, nil)
+
+	// the sibling of a mapped nested field is still unused
+	actual := NewStructMapper(src, dst).MapField("Address.City", "Town").Map()
+
+	unused := []string{}
+	for _, u := range actual.Unused {
+		unused = append(unused, u.Source.Path())
+	}
+	assert.Equal(t, []string{"Address.Street"}, unused)
+
+	actual = NewStructMapper(src, dst).
+		MapField("Address.City", "Town").
+		UseSourceFields(0, "Address.Street").
+		Map()
+	assert.Empty(t, actual.Unused)
+}
+
+func TestMapTypeMismatch(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	var (
+		stringType = types.Universe.Lookup("string").Type()
+		intType    = types.Universe.Lookup("int").Type()
+	)
+
+	var (
+		fooStringVar = types.NewField(1, pkg, "Foo", stringType, false)
+		fooIntVar    = types.NewField(2, pkg, "Foo", intType, false)
+		barIntVar    = types.NewField(3, pkg, "Bar", intType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{fooStringVar}, nil)
+	dst := types.NewStruct([]*types.Var{fooIntVar, barIntVar}, nil)
+
+	actual := NewStructMapper(src, dst).Map()
+
+	assert.Equal(t, []TypeMismatch{
+		{Source: fieldFromVar(fooStringVar), Destination: fieldFromVar(fooIntVar)},
+	}, actual.TypeMismatch)
+	assert.Equal(t, []Field{fieldFromVar(barIntVar)}, actual.NoMatch)
+}
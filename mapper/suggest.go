@@ -0,0 +1,154 @@
+package mapper
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxSuggestions is the most suggestions made for a destination field.
+const maxSuggestions = 3
+
+// minSimilarity is the least name similarity of a suggested source field.
+const minSimilarity = 0.5
+
+var numberWords = map[string]string{
+	"0": "zero", "1": "one", "2": "two", "3": "three", "4": "four",
+	"5": "five", "6": "six", "7": "seven", "8": "eight", "9": "nine",
+}
+
+// suggest returns the unused source fields most likely meant for the
+// unmatched destination fields, best first for each destination field. Only
+// fields with mappable types are suggested, so the suggested MapField calls
+// can be used as is.
+func (m *StructMapper) suggest(noMatch []Field, unused []UnusedField) []Suggestion {
+	suggestions := []Suggestion{}
+	for _, dstField := range noMatch {
+		type scored struct {
+			Suggestion
+			score float64
+		}
+		ranked := []scored{}
+		for _, u := range unused {
+			if !m.typesMappable(u.Source.Type(), dstField.Type()) {
+				continue
+			}
+			score := nameSimilarity(u.Source.Name(), dstField.Name())
+			if score < minSimilarity {
+				continue
+			}
+			ranked = append(ranked, scored{
+				Suggestion: Suggestion{
+					Destination: dstField,
+					Source:      u.Source,
+					SourceIndex: u.SourceIndex,
+				},
+				score: score,
+			})
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].score > ranked[j].score
+		})
+		for i := 0; i < len(ranked) && i < maxSuggestions; i++ {
+			suggestions = append(suggestions, ranked[i].Suggestion)
+		}
+	}
+	return suggestions
+}
+
+// nameSimilarity returns how similar the names are from 0 to 1, the better
+// of the overlap of their words and their edit distance.
+func nameSimilarity(a, b string) float64 {
+	aWords, bWords := nameWords(a), nameWords(b)
+
+	overlap := 0.0
+	if union := len(wordSet(append(append([]string{}, aWords...), bWords...))); union > 0 {
+		common := 0
+		bSet := wordSet(bWords)
+		for w := range wordSet(aWords) {
+			if bSet[w] {
+				common++
+			}
+		}
+		overlap = float64(common) / float64(union)
+	}
+
+	aJoined, bJoined := strings.Join(aWords, ""), strings.Join(bWords, "")
+	longest := len(aJoined)
+	if len(bJoined) > longest {
+		longest = len(bJoined)
+	}
+	distance := 0.0
+	if longest > 0 {
+		distance = 1 - float64(editDistance(aJoined, bJoined))/float64(longest)
+	}
+
+	if overlap > distance {
+		return overlap
+	}
+	return distance
+}
+
+// nameWords splits a Go name in lower case words at case changes, digits and
+// underscores, spelling out digits, so "Field2" is [field two].
+func nameWords(name string) []string {
+	words := []string{}
+	word := []rune{}
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush()
+			continue
+		case unicode.IsDigit(r):
+			flush()
+			if w, ok := numberWords[string(r)]; ok {
+				words = append(words, w)
+				continue
+			}
+			words = append(words, string(r))
+			continue
+		case unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+func wordSet(words []string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// editDistance returns the Levenshtein distance of the strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameWords(t *testing.T) {
+	assert.Equal(t, []string{"field", "two"}, nameWords("Field2"))
+	assert.Equal(t, []string{"field", "two"}, nameWords("FieldTwo"))
+	assert.Equal(t, []string{"user", "id"}, nameWords("UserID"))
+	assert.Equal(t, []string{"http", "server"}, nameWords("HTTPServer"))
+	assert.Equal(t, []string{"xxx", "size"}, nameWords("XXX_size"))
+}
+
+func TestMapSuggestions(t *testing.T) {
+	pkg := types.NewPackage("example.com/mappertest", "mappertest")
+
+	var (
+		stringType = types.Universe.Lookup("string").Type()
+		intType    = types.Universe.Lookup("int").Type()
+	)
+
+	var (
+		fieldTwoVar    = types.NewField(1, pkg, "FieldTwo", intType, false)
+		fieldTwoStrVar = types.NewField(2, pkg, "FieldTwoText", stringType, false)
+		unrelatedVar   = types.NewField(3, pkg, "Unrelated", intType, false)
+		field2Var      = types.NewField(4, pkg, "Field2", intType, false)
+	)
+
+	src := types.NewStruct([]*types.Var{fieldTwoStrVar, unrelatedVar, fieldTwoVar}, nil)
+	dst := types.NewStruct([]*types.Var{field2Var}, nil)
+
+	actual := NewStructMapper(src, dst).Map()
+
+	assert.Equal(t, []Suggestion{
+		{Destination: fieldFromVar(field2Var), Source: fieldFromVar(fieldTwoVar)},
+	}, actual.Suggestions)
+}
@@ -5,13 +5,24 @@ import (
 )
 
 type FieldPair struct {
-	Source      Field
+	Source Field
+	// SourceIndex is the index of the source the field was found in,
+	// 0 unless sources were added with AddSource.
+	SourceIndex int
 	Destination Field
+	// Value is set when the source is a value computed by the caller,
+	// added with MapValue.
+	Value bool
 }
 
 type Field struct {
 	key string
 	ty  types.Type
+	v   *types.Var
+
+	// parents are the fields selected before the field, outermost first,
+	// the embedded fields it is promoted from or the fields of a nested path
+	parents []Field
 }
 
 func (f *Field) Name() string {
@@ -22,14 +33,82 @@ func (f *Field) Type() types.Type {
 	return f.ty
 }
 
+// Parents returns the fields selected before the field, outermost first,
+// so [A, B] for `x.A.B.Field`.
+func (f *Field) Parents() []Field {
+	return f.parents
+}
+
+// Path returns the selector path of the field, `A.B.Field` for `x.A.B.Field`.
+func (f *Field) Path() string {
+	path := ""
+	for _, p := range f.parents {
+		path += p.key + "."
+	}
+	return path + f.key
+}
+
 func fieldFromVar(v *types.Var) Field {
 	return Field{
 		key: v.Name(),
 		ty:  v.Type(),
+		v:   v,
 	}
 }
 
 type MapConfiguration struct {
 	Pairs   []FieldPair
 	NoMatch []Field
+	// Ambiguous are destination fields matching a name declared more than
+	// once at the same depth in the source or the destination.
+	Ambiguous []Field
+	// Ignored are destination fields left unmapped by IgnoreFields or
+	// OnlyFields.
+	Ignored []Field
+	// Inaccessible are unexported destination fields of another package.
+	Inaccessible []Field
+	// Conflicts are destination fields matching several source fields
+	// equally well.
+	Conflicts []Conflict
+	// TypeMismatch are destination fields with a source field matching by
+	// name but with a type that can not be mapped.
+	TypeMismatch []TypeMismatch
+	// Unused are source fields not consumed by any pair.
+	Unused []UnusedField
+	// Suggestions are unused source fields similar to fields in NoMatch,
+	// best first for each destination field.
+	Suggestions []Suggestion
+}
+
+// Suggestion is an unused source field that may be meant for an unmatched
+// destination field.
+type Suggestion struct {
+	Destination Field
+	Source      Field
+	// SourceIndex is the index of the source the field is in.
+	SourceIndex int
+}
+
+// TypeMismatch is a source field matching a destination field by name but
+// not by type.
+type TypeMismatch struct {
+	Source Field
+	// SourceIndex is the index of the source the field is in.
+	SourceIndex int
+	Destination Field
+}
+
+// UnusedField is a field of a source not consumed by any pair.
+type UnusedField struct {
+	Source Field
+	// SourceIndex is the index of the source the field is in.
+	SourceIndex int
+}
+
+// Conflict is a destination field matching several fields of a source.
+type Conflict struct {
+	Destination Field
+	// SourceIndex is the index of the source the candidates are in.
+	SourceIndex int
+	Candidates  []Field
 }
@@ -1,3 +1,12 @@
+// Package typemapper declares mappings between types, generated by the
+// typemapper command.
+//
+// Type conversions of a map use mapping functions, from MapWith,
+// UseConverters or the other maps of the package. A mapping function returns
+// a single destination value and converts its source: methods convert their
+// receiver, and functions convert their last parameter. Any other parameters
+// are context arguments, passed from the parameter of the map with an
+// identical type, which must be unique.
 package typemapper // import "github.com/paultyng/go-typemapper"
 
 const panicNotRuntime = "this should not be invoked at runtime"
@@ -24,8 +33,89 @@ func IgnoreFields(dstFields ...interface{}) {
 	panic(panicNotRuntime)
 }
 
+// IgnoreFieldsMatching tells the map to ignore destination fields, and
+// not match source fields, with names matching any of the glob patterns,
+// for example "XXX_*".
+func IgnoreFieldsMatching(patterns ...string) {
+	panic(panicNotRuntime)
+}
+
+// IgnoreFieldsMatchingRegexp tells the map to ignore destination fields,
+// and not match source fields, with names matching any of the regular
+// expressions.
+func IgnoreFieldsMatchingRegexp(exprs ...string) {
+	panic(panicNotRuntime)
+}
+
+// IgnoreFieldsOfType tells the map to ignore destination fields, and not
+// match source fields, of the types of the values, for example sync.Mutex{}.
+func IgnoreFieldsOfType(values ...interface{}) {
+	panic(panicNotRuntime)
+}
+
+// OnlyFields tells the map to only map certain destination fields,
+// all other destination fields are ignored.
+func OnlyFields(dstFields ...interface{}) {
+	panic(panicNotRuntime)
+}
+
+// IgnoreSourceFields tells the map to not match certain source fields,
+// and to not report them as unused.
+func IgnoreSourceFields(srcFields ...interface{}) {
+	panic(panicNotRuntime)
+}
+
+// Strict tells the generated test of the map to fail when fields of
+// the sources are not used, for example new fields of upstream types.
+func Strict() {
+	panic(panicNotRuntime)
+}
+
+// MinimizeAllocs tells the map to construct the destination with a struct
+// literal and to preallocate slices, to compare against the default code
+// with the generated benchmarks.
+func MinimizeAllocs() {
+	panic(panicNotRuntime)
+}
+
 // MapWith provides additional mapping functions to use for
-// type conversions.
+// type conversions. Methods convert their receiver and functions convert
+// their last parameter. Any other parameters are context arguments, passed
+// from the parameter of the map with an identical type, several parameters
+// of the type are an error.
 func MapWith(mappingFuncs ...interface{}) {
 	panic(panicNotRuntime)
 }
+
+// UseConverters provides the exported functions and methods of the
+// packages as additional mapping functions for type conversions. Several
+// converters for the same types are an error, choose one with MapWith.
+func UseConverters(pkgPaths ...string) {
+	panic(panicNotRuntime)
+}
+
+// DisableAutoMapWith stops the map from using other mapping functions
+// in the package for type conversions unless provided with MapWith.
+func DisableAutoMapWith() {
+	panic(panicNotRuntime)
+}
+
+// MapTransitively tells the map to compose mapping functions through
+// intermediate types when no single mapping function converts a type.
+func MapTransitively() {
+	panic(panicNotRuntime)
+}
+
+// AddSource adds another source to the map, its fields are matched
+// after the fields of the sources before it.
+func AddSource(src interface{}) {
+	panic(panicNotRuntime)
+}
+
+// DeepCopy tells the map to recursively copy pointers, slices, maps and
+// nested structs instead of sharing them between the source and destination.
+// Functions provided with MapWith that return the type they take are used
+// to copy values of that type.
+func DeepCopy() {
+	panic(panicNotRuntime)
+}
@@ -0,0 +1,250 @@
+// Package typemappertest helps testing typemapper declaration packages, with
+// golden files of the generated code and assertions on the fields matched by
+// each mapping.
+package typemappertest // import "github.com/paultyng/go-typemapper/typemappertest"
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/go-typemapper/generator"
+	"github.com/paultyng/go-typemapper/mapper"
+)
+
+// Option configures Generate.
+type Option func(*options)
+
+type options struct {
+	update    *bool
+	args      []string
+	configure []func(*generator.Generator) *generator.Generator
+}
+
+// Update sets if Generate writes the generated files instead of comparing
+// them. By default they are written if the test binary defines an -update
+// flag and it is set.
+func Update(update bool) Option {
+	return func(o *options) {
+		o.update = &update
+	}
+}
+
+// Args generates the files as typemapper run with the command line args, for
+// example "-strict".
+func Args(args ...string) Option {
+	return func(o *options) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// Configure applies configure to the generator before generating the files.
+func Configure(configure func(*generator.Generator) *generator.Generator) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, configure)
+	}
+}
+
+// updateFlag reports if the -update flag of the test binary is set.
+func updateFlag() bool {
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	update, _ := getter.Get().(bool)
+	return update
+}
+
+// Load loads and builds the declaration package in dir.
+func Load(t testing.TB, dir string) *ssa.Package {
+	t.Helper()
+
+	ssapkg, err := generator.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssapkg
+}
+
+// Generate generates the mappings of the declaration package in dir, and
+// compares each generated file with the one in dir, or writes it when
+// updating. The generated files have the header written by typemapper run
+// with the Args.
+func Generate(t *testing.T, dir string, opts ...Option) *generator.Generator {
+	t.Helper()
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	update := updateFlag()
+	if o.update != nil {
+		update = *o.update
+	}
+
+	assert := require.New(t)
+
+	g := newGenerator(Load(t, dir), o.args...)
+	var flags generator.Flags
+	fs := flag.NewFlagSet("typemapper", flag.ContinueOnError)
+	flags.Register(fs)
+	err := fs.Parse(o.args)
+	assert.NoError(err)
+	g = flags.Configure(g)
+	for _, configure := range o.configure {
+		g = configure(g)
+	}
+
+	err = g.GenerateMappings()
+	assert.NoError(err)
+
+	for _, fileName := range g.AllFiles() {
+		t.Run(fileName, func(t *testing.T) {
+			assert := require.New(t)
+
+			outFile := filepath.Join(dir, fileName)
+			expectedBytes, err := os.ReadFile(outFile)
+			if !os.IsNotExist(err) {
+				assert.NoError(err)
+			}
+			expected := strings.ReplaceAll(string(expectedBytes), "\r\n", "\n")
+
+			actualBuf := &bytes.Buffer{}
+			err = g.Render(fileName, actualBuf)
+			assert.NoError(err)
+
+			if update {
+				err = os.WriteFile(outFile, actualBuf.Bytes(), 0644)
+				assert.NoError(err)
+			} else {
+				actual := strings.ReplaceAll(actualBuf.String(), "\r\n", "\n")
+				assert.Equal(expected, actual)
+			}
+		})
+	}
+	return g
+}
+
+func newGenerator(ssapkg *ssa.Package, args ...string) *generator.Generator {
+	return generator.NewGenerator(
+		ssapkg,
+		fmt.Sprintf("// Code generated by \"typemapper %s\"; DO NOT EDIT.\n", strings.Join(args, " ")),
+		fmt.Sprintf("//go:build !%s", generator.BuildTag),
+	)
+}
+
+// Package is a declaration package with its mappings generated.
+type Package struct {
+	g *generator.Generator
+}
+
+var (
+	packagesMu sync.Mutex
+	packages   = map[string]*Package{}
+)
+
+// LoadPackage loads the declaration package in dir and generates its
+// mappings. Packages are loaded once and shared by tests.
+func LoadPackage(t testing.TB, dir string) *Package {
+	t.Helper()
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packagesMu.Lock()
+	defer packagesMu.Unlock()
+	if p, ok := packages[abs]; ok {
+		return p
+	}
+
+	g := newGenerator(Load(t, abs))
+	err = g.GenerateMappings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Package{g: g}
+	packages[abs] = p
+	return p
+}
+
+// MapConfiguration returns the fields matched by the struct mapping with
+// the name.
+func (p *Package) MapConfiguration(t testing.TB, name string) mapper.MapConfiguration {
+	t.Helper()
+
+	mapConfig, err := p.g.MapConfiguration(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mapConfig
+}
+
+// AssertFullyMapped checks each destination field of the struct mapping with
+// the name is mapped or ignored, and reports the other fields as errors.
+func (p *Package) AssertFullyMapped(t testing.TB, name string) bool {
+	t.Helper()
+
+	mapConfig := p.MapConfiguration(t, name)
+	ok := true
+	for _, f := range mapConfig.NoMatch {
+		t.Errorf("%s: no mapping for dst.%s", name, f.Path())
+		ok = false
+	}
+	for _, f := range mapConfig.Ambiguous {
+		t.Errorf("%s: ambiguous selector dst.%s", name, f.Path())
+		ok = false
+	}
+	for _, tm := range mapConfig.TypeMismatch {
+		t.Errorf("%s: type mismatch for dst.%s, %s can not be assigned to %s", name, tm.Destination.Path(), tm.Source.Type(), tm.Destination.Type())
+		ok = false
+	}
+	return ok
+}
+
+// AssertSourcesUsed checks each field of the sources of the struct mapping
+// with the name is mapped, like typemapper.Strict, and reports the other
+// fields as errors.
+func (p *Package) AssertSourcesUsed(t testing.TB, name string) bool {
+	t.Helper()
+
+	mapConfig := p.MapConfiguration(t, name)
+	for _, u := range mapConfig.Unused {
+		t.Errorf("%s: unused source field %s of source %d", name, u.Source.Path(), u.SourceIndex)
+	}
+	return len(mapConfig.Unused) == 0
+}
+
+// MapConfiguration returns the fields matched by the struct mapping with the
+// name in the declaration package of the test.
+func MapConfiguration(t testing.TB, name string) mapper.MapConfiguration {
+	t.Helper()
+	return LoadPackage(t, ".").MapConfiguration(t, name)
+}
+
+// AssertFullyMapped checks each destination field of the struct mapping with
+// the name in the declaration package of the test is mapped or ignored.
+func AssertFullyMapped(t testing.TB, name string) bool {
+	t.Helper()
+	return LoadPackage(t, ".").AssertFullyMapped(t, name)
+}
+
+// AssertSourcesUsed checks each field of the sources of the struct mapping
+// with the name in the declaration package of the test is mapped.
+func AssertSourcesUsed(t testing.TB, name string) bool {
+	t.Helper()
+	return LoadPackage(t, ".").AssertSourcesUsed(t, name)
+}
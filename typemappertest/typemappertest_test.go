@@ -0,0 +1,29 @@
+package typemappertest_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/paultyng/go-typemapper/typemappertest"
+)
+
+// typemappertest must not register -update so that packages using it can
+// define their own flag, as this test does.
+var update = flag.Bool("update", false, "update generated files")
+
+func TestGenerate(t *testing.T) {
+	typemappertest.Generate(t, "../examples/tutorial", typemappertest.Update(*update))
+}
+
+func TestAssertFullyMapped(t *testing.T) {
+	p := typemappertest.LoadPackage(t, "../generator/testdata")
+
+	require.True(t, p.AssertFullyMapped(t, "MapStructSrcDestParams"))
+
+	mapConfig := p.MapConfiguration(t, "MapUserViewUnmatched")
+	require.Len(t, mapConfig.NoMatch, 1)
+	require.Equal(t, "Locale", mapConfig.NoMatch[0].Path())
+	require.Len(t, mapConfig.Ignored, 2)
+}